@@ -0,0 +1,453 @@
+package repository
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage/repository"
+)
+
+// archiveSeparator separates the path of an archive from the path of an item inside it.
+const archiveSeparator = "!"
+
+var _ repository.CustomURIRepository = (*ArchiveRepository)(nil)
+var _ repository.HierarchicalRepository = (*ArchiveRepository)(nil)
+var _ repository.ListableRepository = (*ArchiveRepository)(nil)
+
+// ArchiveFormat identifies the type of archive that an ArchiveRepository reads.
+type ArchiveFormat int
+
+const (
+	// ArchiveZip reads zip archives.
+	ArchiveZip ArchiveFormat = iota
+	// ArchiveTar reads tar archives, which may be compressed with gzip.
+	ArchiveTar
+)
+
+// ArchiveRepository is a read only repository for browsing the contents of zip or tar archives stored in local files.
+// Its URIs join the path of the archive and the path inside it with "!", such as "zip:///home/user/code.zip!/src/main.go".
+// The contents of each archive are read when it is first used, and again if the archive changes.
+type ArchiveRepository struct {
+	scheme   string
+	format   ArchiveFormat
+	archives map[string]*archiveIndex
+	lock     sync.Mutex
+}
+
+type archiveIndex struct {
+	modified time.Time
+	size     int64
+	entries  map[string]*archiveEntry
+	closer   io.Closer
+}
+
+type archiveEntry struct {
+	folder bool
+	file   *zip.File
+}
+
+// NewArchiveRepository creates a repository for archives of the given format and registers it for URIs with the scheme.
+func NewArchiveRepository(scheme string, format ArchiveFormat) *ArchiveRepository {
+	r := &ArchiveRepository{scheme: scheme, format: format, archives: make(map[string]*archiveIndex)}
+	repository.Register(scheme, r)
+	return r
+}
+
+// Root returns the URI of the top-level folder inside the archive at a local path.
+// A relative path is made absolute, so that the URI refers to the same archive when it is parsed again.
+func (r *ArchiveRepository) Root(archive string) fyne.URI {
+	if abs, err := filepath.Abs(archive); err == nil {
+		archive = abs
+	}
+	return &archiveURI{scheme: r.scheme, archive: filepath.ToSlash(archive), inner: "/"}
+}
+
+// CanList returns true if the URI is a folder inside an archive.
+//
+// Implements: repository.ListableRepository
+func (r *ArchiveRepository) CanList(u fyne.URI) (bool, error) {
+	entry, err := r.entry(u)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return entry.folder, nil
+}
+
+// CanRead returns true if the URI is a file inside an archive.
+//
+// Implements: repository.Repository
+func (r *ArchiveRepository) CanRead(u fyne.URI) (bool, error) {
+	entry, err := r.entry(u)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return !entry.folder, nil
+}
+
+// Child returns the URI of an item called name inside the folder at the URI.
+//
+// Implements: repository.HierarchicalRepository
+func (r *ArchiveRepository) Child(u fyne.URI, name string) (fyne.URI, error) {
+	a, err := r.toArchiveURI(u)
+	if err != nil {
+		return nil, err
+	}
+	return &archiveURI{scheme: a.scheme, archive: a.archive, inner: path.Join(a.inner, name)}, nil
+}
+
+// CreateListable is not supported, as archives are read only.
+//
+// Implements: repository.ListableRepository
+func (r *ArchiveRepository) CreateListable(fyne.URI) error {
+	return repository.ErrOperationNotSupported
+}
+
+// Destroy closes the archives that are open when the repository is replaced by another for the same scheme.
+//
+// Implements: repository.Repository
+func (r *ArchiveRepository) Destroy(string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for archive, index := range r.archives {
+		index.close()
+		delete(r.archives, archive)
+	}
+}
+
+// Exists returns true if the URI is a file or folder inside an archive.
+//
+// Implements: repository.Repository
+func (r *ArchiveRepository) Exists(u fyne.URI) (bool, error) {
+	if _, err := r.entry(u); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// List returns the URIs of the items in the folder at the URI, ordered by name.
+//
+// Implements: repository.ListableRepository
+func (r *ArchiveRepository) List(u fyne.URI) ([]fyne.URI, error) {
+	a, err := r.toArchiveURI(u)
+	if err != nil {
+		return nil, err
+	}
+	index, err := r.index(a.archive)
+	if err != nil {
+		return nil, err
+	}
+	if entry, ok := index.entries[a.inner]; !ok || !entry.folder {
+		return nil, &os.PathError{Op: "open", Path: a.Path(), Err: os.ErrNotExist}
+	}
+
+	var names []string
+	prefix := strings.TrimSuffix(a.inner, "/") + "/"
+	for p := range index.entries {
+		if p != "/" && strings.HasPrefix(p, prefix) && !strings.Contains(p[len(prefix):], "/") {
+			names = append(names, p)
+		}
+	}
+	sort.Strings(names)
+
+	uris := make([]fyne.URI, len(names))
+	for i, p := range names {
+		uris[i] = &archiveURI{scheme: a.scheme, archive: a.archive, inner: p}
+	}
+	return uris, nil
+}
+
+// Parent returns the URI of the folder containing the URI. The top-level folder of an archive has no parent.
+//
+// Implements: repository.HierarchicalRepository
+func (r *ArchiveRepository) Parent(u fyne.URI) (fyne.URI, error) {
+	a, err := r.toArchiveURI(u)
+	if err != nil {
+		return nil, err
+	}
+	if a.inner == "/" {
+		return nil, repository.ErrURIRoot
+	}
+	return &archiveURI{scheme: a.scheme, archive: a.archive, inner: path.Dir(a.inner)}, nil
+}
+
+// ParseURI parses a URI with the scheme of this repository.
+//
+// Implements: repository.CustomURIRepository
+func (r *ArchiveRepository) ParseURI(s string) (fyne.URI, error) {
+	rest := s[strings.Index(s, ":")+1:]
+	rest = strings.TrimPrefix(rest, "//")
+	archive, inner := rest, "/"
+	if i := strings.Index(rest, archiveSeparator+"/"); i != -1 {
+		archive, inner = rest[:i], rest[i+len(archiveSeparator):]
+	} else {
+		archive = strings.TrimSuffix(rest, archiveSeparator)
+	}
+	if archive == "" {
+		return nil, repository.ErrOperationNotSupported
+	}
+	return &archiveURI{scheme: r.scheme, archive: archive, inner: path.Clean(inner)}, nil
+}
+
+// Reader opens a file inside an archive for reading.
+//
+// Implements: repository.Repository
+func (r *ArchiveRepository) Reader(u fyne.URI) (fyne.URIReadCloser, error) {
+	a, err := r.toArchiveURI(u)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := r.entry(a)
+	if err != nil {
+		return nil, err
+	}
+	if entry.folder {
+		return nil, &os.PathError{Op: "read", Path: a.Path(), Err: repository.ErrOperationNotSupported}
+	}
+
+	var data []byte
+	if entry.file != nil {
+		var f io.ReadCloser
+		if f, err = entry.file.Open(); err != nil {
+			return nil, err
+		}
+		data, err = ioutil.ReadAll(f)
+		f.Close()
+	} else {
+		data, err = r.readTarFile(a.archive, a.inner)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &memoryReader{ReadCloser: ioutil.NopCloser(bytes.NewReader(data)), uri: u}, nil
+}
+
+func (r *ArchiveRepository) entry(u fyne.URI) (*archiveEntry, error) {
+	a, err := r.toArchiveURI(u)
+	if err != nil {
+		return nil, err
+	}
+	index, err := r.index(a.archive)
+	if err != nil {
+		return nil, err
+	}
+	entry, ok := index.entries[a.inner]
+	if !ok {
+		return nil, &os.PathError{Op: "stat", Path: a.Path(), Err: os.ErrNotExist}
+	}
+	return entry, nil
+}
+
+// index returns the entries of an archive, reading them if the archive has not been read or has changed since.
+func (r *ArchiveRepository) index(archive string) (*archiveIndex, error) {
+	info, err := os.Stat(filepath.FromSlash(archive))
+	if err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	old, ok := r.archives[archive]
+	if ok && old.modified.Equal(info.ModTime()) && old.size == info.Size() {
+		return old, nil
+	}
+
+	index := &archiveIndex{modified: info.ModTime(), size: info.Size(), entries: map[string]*archiveEntry{"/": {folder: true}}}
+	if r.format == ArchiveZip {
+		err = readZipIndex(archive, index)
+	} else {
+		err = readTarIndex(archive, index)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if old != nil {
+		old.close()
+	}
+	r.archives[archive] = index
+	return index, nil
+}
+
+// readTarFile reads the content of a file inside a tar archive, which requires reading the archive up to it.
+func (r *ArchiveRepository) readTarFile(archive, inner string) ([]byte, error) {
+	f, reader, err := openTar(archive)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	for {
+		header, err := reader.Next()
+		if err != nil {
+			if err == io.EOF {
+				return nil, &os.PathError{Op: "open", Path: inner, Err: os.ErrNotExist}
+			}
+			return nil, err
+		}
+		if path.Clean("/"+header.Name) == inner {
+			return ioutil.ReadAll(reader)
+		}
+	}
+}
+
+func (r *ArchiveRepository) toArchiveURI(u fyne.URI) (*archiveURI, error) {
+	if a, ok := u.(*archiveURI); ok {
+		return a, nil
+	}
+	parsed, err := r.ParseURI(u.String())
+	if err != nil {
+		return nil, err
+	}
+	return parsed.(*archiveURI), nil
+}
+
+// addArchiveEntry records an item of an archive, and the folders containing it which archives may leave out.
+func addArchiveEntry(index *archiveIndex, name string, entry *archiveEntry) {
+	p := path.Clean("/" + name)
+	if p == "/" {
+		return
+	}
+	index.entries[p] = entry
+	for dir := path.Dir(p); dir != "/"; dir = path.Dir(dir) {
+		if _, ok := index.entries[dir]; !ok {
+			index.entries[dir] = &archiveEntry{folder: true}
+		}
+	}
+}
+
+// openTar opens a tar archive, decompressing it if it starts with the gzip header.
+func openTar(archive string) (io.Closer, *tar.Reader, error) {
+	f, err := os.Open(filepath.FromSlash(archive))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	buffered := bufio.NewReader(f)
+	if magic, err := buffered.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		decompressed, err := gzip.NewReader(buffered)
+		if err != nil {
+			f.Close()
+			return nil, nil, err
+		}
+		return f, tar.NewReader(decompressed), nil
+	}
+	return f, tar.NewReader(buffered), nil
+}
+
+func readTarIndex(archive string, index *archiveIndex) error {
+	f, reader, err := openTar(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			addArchiveEntry(index, header.Name, &archiveEntry{folder: true})
+		case tar.TypeReg, tar.TypeRegA:
+			addArchiveEntry(index, header.Name, &archiveEntry{})
+		}
+	}
+}
+
+func readZipIndex(archive string, index *archiveIndex) error {
+	reader, err := zip.OpenReader(filepath.FromSlash(archive))
+	if err != nil {
+		return err
+	}
+	// the files are opened from the archive each time they are read, so it is closed when the index is replaced
+	index.closer = reader
+	for _, f := range reader.File {
+		if strings.HasSuffix(f.Name, "/") {
+			addArchiveEntry(index, f.Name, &archiveEntry{folder: true})
+		} else {
+			addArchiveEntry(index, f.Name, &archiveEntry{file: f})
+		}
+	}
+	return nil
+}
+
+func (i *archiveIndex) close() {
+	if i.closer != nil {
+		_ = i.closer.Close()
+	}
+}
+
+type archiveURI struct {
+	scheme, archive, inner string
+}
+
+func (u *archiveURI) Authority() string {
+	return ""
+}
+
+func (u *archiveURI) Extension() string {
+	return path.Ext(u.Name())
+}
+
+func (u *archiveURI) Fragment() string {
+	return ""
+}
+
+func (u *archiveURI) MimeType() string {
+	if u.inner == "/" {
+		return "inode/directory"
+	}
+	if mimeType := mime.TypeByExtension(u.Extension()); mimeType != "" {
+		return mimeType
+	}
+	return "application/octet-stream"
+}
+
+func (u *archiveURI) Name() string {
+	if u.inner == "/" {
+		return path.Base(u.archive)
+	}
+	return path.Base(u.inner)
+}
+
+func (u *archiveURI) Path() string {
+	return u.archive + archiveSeparator + u.inner
+}
+
+func (u *archiveURI) Query() string {
+	return ""
+}
+
+func (u *archiveURI) Scheme() string {
+	return u.scheme
+}
+
+func (u *archiveURI) String() string {
+	return u.scheme + "://" + u.archive + archiveSeparator + u.inner
+}
@@ -0,0 +1,140 @@
+package repository_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2/storage"
+
+	"fyne.io/x/fyne/storage/repository"
+)
+
+func TestArchiveRepository_Zip(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	archive := filepath.Join(tempDir, "code.zip")
+	writeZip(t, archive, map[string]string{"src/main.go": "package main", "README": "readme"})
+
+	zips := repository.NewArchiveRepository("ziptest", repository.ArchiveZip)
+	root := zips.Root(archive)
+	assert.Equal(t, "code.zip", root.Name())
+
+	list, err := storage.List(root)
+	assert.NoError(t, err)
+	prefix := "ziptest://" + filepath.ToSlash(archive) + "!"
+	assert.Equal(t, []string{prefix + "/README", prefix + "/src"}, uriStrings(list))
+
+	src := list[1]
+	ok, err := storage.CanList(src) // implicit folder
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	main, err := storage.Child(src, "main.go")
+	assert.NoError(t, err)
+	assert.Equal(t, "package main", readString(t, main))
+
+	parent, err := storage.Parent(main)
+	assert.NoError(t, err)
+	assert.Equal(t, src.String(), parent.String())
+	_, err = storage.Parent(root)
+	assert.Error(t, err)
+
+	parsed, err := storage.ParseURI(main.String())
+	assert.NoError(t, err)
+	assert.Equal(t, main.String(), parsed.String())
+	assert.Equal(t, "main.go", parsed.Name())
+
+	missing, _ := storage.Child(root, "missing")
+	exists, err := storage.Exists(missing)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	assert.Error(t, storage.CreateListable(missing))
+
+	// a changed archive is read again
+	writeZip(t, archive, map[string]string{"other.txt": "other"})
+	future := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(archive, future, future))
+	list, err = storage.List(root)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{prefix + "/other.txt"}, uriStrings(list))
+}
+
+func TestArchiveRepository_Tar(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	archive := filepath.Join(tempDir, "code.tar.gz")
+	writeTarGz(t, archive, map[string]string{"a/b/c.txt": "c", "d.txt": "d"})
+
+	tars := repository.NewArchiveRepository("tartest", repository.ArchiveTar)
+	root := tars.Root(archive)
+	list, err := storage.List(root)
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "a", list[0].Name())
+	assert.Equal(t, "d", readString(t, list[1]))
+
+	c, _ := storage.ParseURI(root.String() + "a/b/c.txt")
+	assert.Equal(t, "c", readString(t, c))
+	ok, _ := storage.CanRead(list[0])
+	assert.False(t, ok)
+}
+
+func TestArchiveRepository_RelativePath(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	writeZip(t, filepath.Join(tempDir, "code.zip"), map[string]string{"README": "readme"})
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(tempDir))
+	defer os.Chdir(wd)
+
+	zips := repository.NewArchiveRepository("ziprelative", repository.ArchiveZip)
+	root := zips.Root("code.zip")
+	parsed, err := storage.ParseURI(root.String())
+	assert.NoError(t, err)
+	assert.Equal(t, root.String(), parsed.String())
+
+	readme, err := storage.Child(parsed, "README")
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(wd))
+	assert.Equal(t, "readme", readString(t, readme))
+}
+
+func writeTarGz(t *testing.T, archive string, files map[string]string) {
+	f, err := os.Create(archive)
+	assert.NoError(t, err)
+	defer f.Close()
+	compressed := gzip.NewWriter(f)
+	defer compressed.Close()
+	w := tar.NewWriter(compressed)
+	defer w.Close()
+	for name, content := range files {
+		assert.NoError(t, w.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+}
+
+func writeZip(t *testing.T, archive string, files map[string]string) {
+	f, err := os.Create(archive)
+	assert.NoError(t, err)
+	defer f.Close()
+	w := zip.NewWriter(f)
+	defer w.Close()
+	for name, content := range files {
+		entry, err := w.Create(name)
+		assert.NoError(t, err)
+		_, err = entry.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+}
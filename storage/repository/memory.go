@@ -0,0 +1,329 @@
+// Package repository provides storage repositories that can be browsed with the FileTree widget.
+package repository // import "fyne.io/x/fyne/storage/repository"
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/storage/repository"
+)
+
+var _ repository.CopyableRepository = (*MemoryRepository)(nil)
+var _ repository.HierarchicalRepository = (*MemoryRepository)(nil)
+var _ repository.ListableRepository = (*MemoryRepository)(nil)
+var _ repository.MovableRepository = (*MemoryRepository)(nil)
+var _ repository.WritableRepository = (*MemoryRepository)(nil)
+
+// MemoryRepository keeps a hierarchy of files and folders in memory.
+// It can be used to show generated content, or to test code that uses storage without touching the disk.
+type MemoryRepository struct {
+	scheme string
+	items  map[string]*memoryItem
+	lock   sync.RWMutex
+}
+
+type memoryItem struct {
+	data     []byte
+	folder   bool
+	modified time.Time
+}
+
+// NewMemoryRepository creates an empty in-memory repository and registers it for URIs with the given scheme.
+func NewMemoryRepository(scheme string) *MemoryRepository {
+	r := &MemoryRepository{
+		scheme: scheme,
+		items:  map[string]*memoryItem{"/": {folder: true, modified: time.Now()}},
+	}
+	repository.Register(scheme, r)
+	return r
+}
+
+// AddFile stores a file with the given content at a path, creating any folders that it is inside.
+// It returns the URI of the file.
+func (r *MemoryRepository) AddFile(p string, data []byte) fyne.URI {
+	p = cleanPath(p)
+	r.lock.Lock()
+	r.addFolders(path.Dir(p))
+	r.items[p] = &memoryItem{data: append([]byte{}, data...), modified: time.Now()}
+	r.lock.Unlock()
+	return r.uri(p)
+}
+
+// AddFolder creates a folder at a path, and any folders that it is inside. It returns the URI of the folder.
+func (r *MemoryRepository) AddFolder(p string) fyne.URI {
+	p = cleanPath(p)
+	r.lock.Lock()
+	r.addFolders(p)
+	r.lock.Unlock()
+	return r.uri(p)
+}
+
+// Root returns the URI of the top-level folder of the repository.
+func (r *MemoryRepository) Root() fyne.URI {
+	return r.uri("/")
+}
+
+// CanList returns true if the URI is a folder.
+//
+// Implements: repository.ListableRepository
+func (r *MemoryRepository) CanList(u fyne.URI) (bool, error) {
+	item := r.item(u)
+	return item != nil && item.folder, nil
+}
+
+// CanRead returns true if the URI is a file.
+//
+// Implements: repository.Repository
+func (r *MemoryRepository) CanRead(u fyne.URI) (bool, error) {
+	item := r.item(u)
+	return item != nil && !item.folder, nil
+}
+
+// CanWrite returns true if the URI is a file, or could be created as a file because its folder exists.
+//
+// Implements: repository.WritableRepository
+func (r *MemoryRepository) CanWrite(u fyne.URI) (bool, error) {
+	p := cleanPath(u.Path())
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if item, ok := r.items[p]; ok {
+		return !item.folder, nil
+	}
+	parent, ok := r.items[path.Dir(p)]
+	return ok && parent.folder, nil
+}
+
+// Child returns the URI of an item called name inside the folder at the URI.
+//
+// Implements: repository.HierarchicalRepository
+func (r *MemoryRepository) Child(u fyne.URI, name string) (fyne.URI, error) {
+	return repository.GenericChild(u, name)
+}
+
+// Copy copies the file or folder at the source URI to the destination URI.
+//
+// Implements: repository.CopyableRepository
+func (r *MemoryRepository) Copy(source, destination fyne.URI) error {
+	if destination.Scheme() != r.scheme {
+		return repository.GenericCopy(source, destination)
+	}
+	return r.transfer(source, destination, false)
+}
+
+// CreateListable creates a folder at the URI, the folder containing it must already exist.
+//
+// Implements: repository.ListableRepository
+func (r *MemoryRepository) CreateListable(u fyne.URI) error {
+	p := cleanPath(u.Path())
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.items[p]; ok {
+		return &os.PathError{Op: "mkdir", Path: p, Err: os.ErrExist}
+	}
+	if parent, ok := r.items[path.Dir(p)]; !ok || !parent.folder {
+		return &os.PathError{Op: "mkdir", Path: p, Err: os.ErrNotExist}
+	}
+	r.items[p] = &memoryItem{folder: true, modified: time.Now()}
+	return nil
+}
+
+// Delete removes the file or empty folder at the URI.
+//
+// Implements: repository.WritableRepository
+func (r *MemoryRepository) Delete(u fyne.URI) error {
+	p := cleanPath(u.Path())
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.items[p]; !ok || p == "/" {
+		return &os.PathError{Op: "remove", Path: p, Err: os.ErrNotExist}
+	}
+	if len(r.children(p)) > 0 {
+		return &os.PathError{Op: "remove", Path: p, Err: os.ErrExist}
+	}
+	delete(r.items, p)
+	return nil
+}
+
+// Destroy is called when the repository is replaced by another for the same scheme.
+//
+// Implements: repository.Repository
+func (r *MemoryRepository) Destroy(string) {
+}
+
+// Exists returns true if there is a file or folder at the URI.
+//
+// Implements: repository.Repository
+func (r *MemoryRepository) Exists(u fyne.URI) (bool, error) {
+	return r.item(u) != nil, nil
+}
+
+// List returns the URIs of the items in the folder at the URI, ordered by name.
+//
+// Implements: repository.ListableRepository
+func (r *MemoryRepository) List(u fyne.URI) ([]fyne.URI, error) {
+	p := cleanPath(u.Path())
+	r.lock.RLock()
+	item, ok := r.items[p]
+	if !ok || !item.folder {
+		r.lock.RUnlock()
+		return nil, &os.PathError{Op: "open", Path: p, Err: os.ErrNotExist}
+	}
+	children := r.children(p)
+	r.lock.RUnlock()
+
+	uris := make([]fyne.URI, len(children))
+	for i, child := range children {
+		uris[i] = r.uri(child)
+	}
+	return uris, nil
+}
+
+// Move moves the file or folder at the source URI to the destination URI.
+//
+// Implements: repository.MovableRepository
+func (r *MemoryRepository) Move(source, destination fyne.URI) error {
+	if destination.Scheme() != r.scheme {
+		return repository.GenericMove(source, destination)
+	}
+	return r.transfer(source, destination, true)
+}
+
+// Parent returns the URI of the folder containing the URI.
+//
+// Implements: repository.HierarchicalRepository
+func (r *MemoryRepository) Parent(u fyne.URI) (fyne.URI, error) {
+	return repository.GenericParent(u)
+}
+
+// Reader opens the file at the URI for reading.
+//
+// Implements: repository.Repository
+func (r *MemoryRepository) Reader(u fyne.URI) (fyne.URIReadCloser, error) {
+	item := r.item(u)
+	if item == nil || item.folder {
+		return nil, &os.PathError{Op: "open", Path: u.Path(), Err: os.ErrNotExist}
+	}
+	return &memoryReader{ReadCloser: ioutil.NopCloser(bytes.NewReader(item.data)), uri: u}, nil
+}
+
+// Writer opens the file at the URI for writing, replacing its content when it is closed.
+//
+// Implements: repository.WritableRepository
+func (r *MemoryRepository) Writer(u fyne.URI) (fyne.URIWriteCloser, error) {
+	if ok, _ := r.CanWrite(u); !ok {
+		return nil, &os.PathError{Op: "open", Path: u.Path(), Err: os.ErrPermission}
+	}
+	return &memoryWriter{repo: r, uri: u}, nil
+}
+
+func (r *MemoryRepository) addFolders(p string) {
+	for dir := p; ; dir = path.Dir(dir) {
+		if _, ok := r.items[dir]; !ok {
+			r.items[dir] = &memoryItem{folder: true, modified: time.Now()}
+		}
+		if dir == "/" {
+			return
+		}
+	}
+}
+
+// children returns the sorted paths directly inside a folder, the lock must be held.
+func (r *MemoryRepository) children(p string) (children []string) {
+	prefix := strings.TrimSuffix(p, "/") + "/"
+	for child := range r.items {
+		if child != "/" && strings.HasPrefix(child, prefix) && !strings.Contains(child[len(prefix):], "/") {
+			children = append(children, child)
+		}
+	}
+	sort.Strings(children)
+	return children
+}
+
+func (r *MemoryRepository) item(u fyne.URI) *memoryItem {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.items[cleanPath(u.Path())]
+}
+
+// transfer copies or moves an item and everything inside it to a new path in this repository.
+func (r *MemoryRepository) transfer(source, destination fyne.URI, move bool) error {
+	from, to := cleanPath(source.Path()), cleanPath(destination.Path())
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.items[from]; !ok {
+		return &os.PathError{Op: "rename", Path: from, Err: os.ErrNotExist}
+	}
+	if parent, ok := r.items[path.Dir(to)]; !ok || !parent.folder {
+		return &os.PathError{Op: "rename", Path: to, Err: os.ErrNotExist}
+	}
+	if to == from || strings.HasPrefix(to, from+"/") {
+		return repository.ErrOperationNotSupported
+	}
+	if _, ok := r.items[to]; ok {
+		return &os.PathError{Op: "rename", Path: to, Err: os.ErrExist}
+	}
+
+	now := time.Now()
+	for p, item := range r.items {
+		if p != from && !strings.HasPrefix(p, from+"/") {
+			continue
+		}
+		r.items[to+p[len(from):]] = &memoryItem{data: item.data, folder: item.folder, modified: now}
+		if move {
+			delete(r.items, p)
+		}
+	}
+	return nil
+}
+
+func (r *MemoryRepository) uri(p string) fyne.URI {
+	u, err := storage.ParseURI(r.scheme + "://" + p)
+	if err != nil {
+		fyne.LogError("Unable to create URI for "+p, err)
+	}
+	return u
+}
+
+// cleanPath returns an absolute path without a trailing slash, or "/" for the root.
+func cleanPath(p string) string {
+	return path.Clean("/" + p)
+}
+
+type memoryReader struct {
+	io.ReadCloser
+	uri fyne.URI
+}
+
+func (r *memoryReader) URI() fyne.URI {
+	return r.uri
+}
+
+type memoryWriter struct {
+	bytes.Buffer
+	repo *MemoryRepository
+	uri  fyne.URI
+}
+
+func (w *memoryWriter) Close() error {
+	p := cleanPath(w.uri.Path())
+	w.repo.lock.Lock()
+	defer w.repo.lock.Unlock()
+	if parent, ok := w.repo.items[path.Dir(p)]; !ok || !parent.folder {
+		return &os.PathError{Op: "close", Path: p, Err: os.ErrNotExist}
+	}
+	w.repo.items[p] = &memoryItem{data: append([]byte{}, w.Bytes()...), modified: time.Now()}
+	return nil
+}
+
+func (w *memoryWriter) URI() fyne.URI {
+	return w.uri
+}
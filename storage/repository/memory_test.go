@@ -0,0 +1,112 @@
+package repository_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+
+	"fyne.io/x/fyne/storage/repository"
+	"fyne.io/x/fyne/widget"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	mem := repository.NewMemoryRepository("memtest")
+	readme := mem.AddFile("/docs/readme.txt", []byte("hello"))
+	mem.AddFolder("/empty")
+
+	assert.Equal(t, "memtest:///docs/readme.txt", readme.String())
+	list, err := storage.List(mem.Root())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"memtest:///docs", "memtest:///empty"}, uriStrings(list))
+
+	docs, _ := storage.Parent(readme)
+	ok, err := storage.CanList(docs)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, _ = storage.CanList(readme)
+	assert.False(t, ok)
+	assert.Equal(t, "hello", readString(t, readme))
+
+	w, err := storage.Writer(readme)
+	assert.NoError(t, err)
+	_, _ = w.Write([]byte("changed"))
+	assert.NoError(t, w.Close())
+	assert.Equal(t, "changed", readString(t, readme))
+
+	missing, _ := storage.Child(mem.Root(), "missing")
+	orphan, _ := storage.Child(missing, "file.txt")
+	_, err = storage.Writer(orphan)
+	assert.Error(t, err)
+	assert.Error(t, storage.Delete(docs)) // not empty
+}
+
+func TestMemoryRepository_CopyMove(t *testing.T) {
+	mem := repository.NewMemoryRepository("memtest")
+	mem.AddFile("/src/a.txt", []byte("a"))
+	mem.AddFile("/src/sub/b.txt", []byte("b"))
+	mem.AddFile("/a.txt", []byte("a"))
+	src, _ := storage.ParseURI("memtest:///src")
+	dst, _ := storage.ParseURI("memtest:///dst")
+
+	assert.NoError(t, storage.Copy(src, dst))
+	list, _ := storage.List(dst)
+	assert.Equal(t, []string{"memtest:///dst/a.txt", "memtest:///dst/sub"}, uriStrings(list))
+
+	moved, _ := storage.ParseURI("memtest:///moved")
+	assert.NoError(t, storage.Move(src, moved))
+	exists, _ := storage.Exists(src)
+	assert.False(t, exists)
+	b, _ := storage.ParseURI("memtest:///moved/sub/b.txt")
+	assert.Equal(t, "b", readString(t, b))
+	a, _ := storage.ParseURI("memtest:///a.txt")
+
+	inside, _ := storage.Child(moved, "inside")
+	assert.Error(t, storage.Move(moved, inside))
+
+	err := storage.Move(moved, dst) // existing items are not replaced
+	assert.True(t, os.IsExist(err))
+	err = storage.Copy(b, a)
+	assert.True(t, os.IsExist(err))
+	assert.Equal(t, "a", readString(t, a))
+	assert.Equal(t, "b", readString(t, b))
+}
+
+func TestMemoryRepository_FileTree(t *testing.T) {
+	test.NewApp()
+
+	mem := repository.NewMemoryRepository("memtest")
+	mem.AddFile("/a/b.txt", nil)
+	mem.AddFile("/c.txt", nil)
+	tree := widget.NewFileTree(mem.Root())
+	tree.OpenAllBranches()
+
+	assert.Equal(t, []string{"memtest:///a", "memtest:///c.txt"}, tree.ChildUIDs(mem.Root().String()))
+	assert.Equal(t, []string{"memtest:///a/b.txt"}, tree.ChildUIDs("memtest:///a"))
+	assert.True(t, tree.IsBranch("memtest:///a"))
+	assert.False(t, tree.IsBranch("memtest:///c.txt"))
+}
+
+func readString(t *testing.T, u fyne.URI) string {
+	r, err := storage.Reader(u)
+	if !assert.NoError(t, err) {
+		return ""
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	return string(data)
+}
+
+func uriStrings(uris []fyne.URI) []string {
+	s := make([]string, len(uris))
+	for i, u := range uris {
+		s[i] = u.String()
+	}
+	return s
+}
@@ -0,0 +1,133 @@
+package widget
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// AlphaDisplay shows text across a row of AlphaWidget characters. Text is shown from the left, and is cut
+// short if it is longer than the display. The colour, size and slant are shared by all of the characters.
+type AlphaDisplay struct {
+	widget.BaseWidget
+
+	chars []*AlphaWidget
+	count AlphaSegments
+	text  string
+
+	size      fyne.Size
+	hexOffset float32
+	onColor   color.Color
+	offColor  color.Color
+}
+
+// NewAlphaDisplay creates a blank display with the given number of characters, each drawn with the given
+// number of segments.
+func NewAlphaDisplay(length int, count AlphaSegments) *AlphaDisplay {
+	a := &AlphaDisplay{
+		count:     count,
+		size:      fyne.NewSize(defaultHexWidth, defaultHexHeight),
+		hexOffset: defaultHexOffset,
+	}
+	a.ExtendBaseWidget(a)
+	a.SetLength(length)
+	return a
+}
+
+// CreateRenderer implements fyne.Widget
+func (a *AlphaDisplay) CreateRenderer() fyne.WidgetRenderer {
+	r := &digitRowRenderer{digits: func() []fyne.CanvasObject {
+		objects := make([]fyne.CanvasObject, len(a.chars))
+		for i, c := range a.chars {
+			objects[i] = c
+		}
+		return objects
+	}}
+	r.Refresh()
+	return r
+}
+
+// SetLength changes the number of characters in the display, which is at least 1.
+func (a *AlphaDisplay) SetLength(length int) {
+	if length < 1 {
+		length = 1
+	}
+	for len(a.chars) > length {
+		a.chars = a.chars[:len(a.chars)-1]
+	}
+	for len(a.chars) < length {
+		c := NewAlphaWidget(a.count)
+		c.size, c.hexOffset, c.onColor, c.offColor = a.size, a.hexOffset, a.onColor, a.offColor
+		a.chars = append(a.chars, c)
+	}
+	a.update()
+}
+
+// SetOffColor changes the color that segments are shown as when they are inactive/off.
+// Passing nil returns to a faint version of the on color.
+func (a *AlphaDisplay) SetOffColor(c color.Color) {
+	a.offColor = c
+	for _, char := range a.chars {
+		char.SetOffColor(c)
+	}
+}
+
+// SetOnColor changes the color that segments are shown as when they are active/on.
+// Passing nil returns to the primary color of the theme.
+func (a *AlphaDisplay) SetOnColor(c color.Color) {
+	a.onColor = c
+	for _, char := range a.chars {
+		char.SetOnColor(c)
+	}
+}
+
+// SetSize changes the size of each character of the display.
+func (a *AlphaDisplay) SetSize(s fyne.Size) {
+	a.size = s
+	for _, char := range a.chars {
+		char.SetSize(s)
+	}
+	a.Refresh()
+}
+
+// SetSlant changes the amount of "slant" in each character, see HexWidget.SetSlant.
+func (a *AlphaDisplay) SetSlant(s float32) {
+	a.hexOffset = s
+	for _, char := range a.chars {
+		char.SetSlant(s)
+	}
+	a.Refresh()
+}
+
+// SetText changes the text shown by the display.
+func (a *AlphaDisplay) SetText(text string) {
+	a.text = text
+	a.update()
+}
+
+// Text returns the text shown by the display, including any that does not fit.
+func (a *AlphaDisplay) Text() string {
+	return a.text
+}
+
+// UpdateSegments sets the raw state of the segments of the character at an index, see AlphaWidget.UpdateSegments.
+// It is replaced when the text or length is changed.
+func (a *AlphaDisplay) UpdateSegments(index int, segments uint16) {
+	if index < 0 || index >= len(a.chars) {
+		return
+	}
+	a.chars[index].UpdateSegments(segments)
+}
+
+func (a *AlphaDisplay) update() {
+	runes := []rune(a.text)
+	for i, c := range a.chars {
+		if i < len(runes) {
+			c.Set(runes[i])
+		} else {
+			c.UpdateSegments(alphaBlank)
+		}
+	}
+	a.Refresh()
+}
@@ -0,0 +1,54 @@
+package widget
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestAlphaDisplay_SetText(t *testing.T) {
+	a := NewAlphaDisplay(4, SixteenSegments)
+	a.SetText("Hi!")
+	assert.Equal(t, []uint16{alphaSegments('H'), alphaSegments('I'), alphaSegments('!'), alphaBlank}, alphaDisplayed(a))
+
+	a.SetText("TOO LONG")
+	assert.Equal(t, "TOO LONG", a.Text())
+	assert.Equal(t, []uint16{alphaSegments('T'), alphaSegments('O'), alphaSegments('O'), alphaBlank}, alphaDisplayed(a))
+
+	a.SetLength(8)
+	assert.Equal(t, alphaSegments('G'), alphaDisplayed(a)[7])
+
+	a.UpdateSegments(0, ^alphaG)
+	a.UpdateSegments(8, 0) // ignored
+	assert.Equal(t, ^alphaG, alphaDisplayed(a)[0])
+}
+
+func TestAlphaDisplay_SharedSettings(t *testing.T) {
+	test.NewApp()
+
+	a := NewAlphaDisplay(2, FourteenSegments)
+	a.SetSize(fyne.NewSize(30, 50))
+	a.SetSlant(2)
+	window := test.NewWindow(a)
+	defer window.Close()
+
+	a.SetLength(3)
+	for _, c := range a.chars {
+		assert.Equal(t, fyne.NewSize(30, 50), c.size)
+		assert.Equal(t, float32(2), c.hexOffset)
+		assert.Equal(t, FourteenSegments, c.count)
+	}
+	char := a.chars[0].MinSize()
+	assert.Equal(t, fyne.NewSize(char.Width*3, char.Height), a.MinSize())
+}
+
+func alphaDisplayed(a *AlphaDisplay) []uint16 {
+	segments := make([]uint16, len(a.chars))
+	for i, c := range a.chars {
+		segments[i] = c.segments
+	}
+	return segments
+}
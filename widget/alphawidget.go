@@ -0,0 +1,336 @@
+package widget
+
+import (
+	"image/color"
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// AlphaSegments is the number of segments that an AlphaWidget draws.
+type AlphaSegments int
+
+const (
+	// FourteenSegments draws the top and bottom segments as single lines.
+	FourteenSegments AlphaSegments = 14
+	// SixteenSegments splits the top and bottom segments in two, like the middle segment.
+	SixteenSegments AlphaSegments = 16
+)
+
+// the bits of the segments of an AlphaWidget, see the AlphaWidget documentation for their positions
+const (
+	alphaA1 uint16 = 1 << iota
+	alphaA2
+	alphaB
+	alphaC
+	alphaD2
+	alphaD1
+	alphaE
+	alphaF
+	alphaG1
+	alphaG2
+	alphaH
+	alphaI
+	alphaJ
+	alphaK
+	alphaL
+	alphaM
+
+	alphaA = alphaA1 | alphaA2
+	alphaD = alphaD1 | alphaD2
+	alphaG = alphaG1 | alphaG2
+)
+
+// alphaBlank is the segment state with every segment off.
+const alphaBlank uint16 = 0xffff
+
+// alphaLookupTable is used by a.Set() - the i-th index into this table holds
+// the segments that are lit to show the ASCII character i. The raw value sent
+// to UpdateSegments is its inverse, as segments are active-low. Lower case
+// letters are shown in upper case.
+var alphaLookupTable = [128]uint16{
+	'0': alphaA | alphaB | alphaC | alphaD | alphaE | alphaF | alphaJ | alphaM,
+	'1': alphaB | alphaC | alphaJ,
+	'2': alphaA | alphaB | alphaG | alphaE | alphaD,
+	'3': alphaA | alphaB | alphaG2 | alphaC | alphaD,
+	'4': alphaF | alphaG | alphaB | alphaC,
+	'5': alphaA | alphaF | alphaG | alphaC | alphaD,
+	'6': alphaA | alphaF | alphaE | alphaD | alphaC | alphaG,
+	'7': alphaA | alphaB | alphaC,
+	'8': alphaA | alphaB | alphaC | alphaD | alphaE | alphaF | alphaG,
+	'9': alphaA | alphaB | alphaC | alphaD | alphaF | alphaG,
+
+	'A': alphaA | alphaB | alphaC | alphaE | alphaF | alphaG,
+	'B': alphaA | alphaB | alphaC | alphaD | alphaG2 | alphaI | alphaL,
+	'C': alphaA | alphaD | alphaE | alphaF,
+	'D': alphaA | alphaB | alphaC | alphaD | alphaI | alphaL,
+	'E': alphaA | alphaD | alphaE | alphaF | alphaG1,
+	'F': alphaA | alphaE | alphaF | alphaG1,
+	'G': alphaA | alphaC | alphaD | alphaE | alphaF | alphaG2,
+	'H': alphaB | alphaC | alphaE | alphaF | alphaG,
+	'I': alphaA | alphaD | alphaI | alphaL,
+	'J': alphaB | alphaC | alphaD | alphaE,
+	'K': alphaE | alphaF | alphaG1 | alphaJ | alphaK,
+	'L': alphaD | alphaE | alphaF,
+	'M': alphaB | alphaC | alphaE | alphaF | alphaH | alphaJ,
+	'N': alphaB | alphaC | alphaE | alphaF | alphaH | alphaK,
+	'O': alphaA | alphaB | alphaC | alphaD | alphaE | alphaF,
+	'P': alphaA | alphaB | alphaE | alphaF | alphaG,
+	'Q': alphaA | alphaB | alphaC | alphaD | alphaE | alphaF | alphaK,
+	'R': alphaA | alphaB | alphaE | alphaF | alphaG | alphaK,
+	'S': alphaA | alphaC | alphaD | alphaF | alphaG,
+	'T': alphaA | alphaI | alphaL,
+	'U': alphaB | alphaC | alphaD | alphaE | alphaF,
+	'V': alphaE | alphaF | alphaJ | alphaM,
+	'W': alphaB | alphaC | alphaE | alphaF | alphaK | alphaM,
+	'X': alphaH | alphaJ | alphaK | alphaM,
+	'Y': alphaH | alphaJ | alphaL,
+	'Z': alphaA | alphaD | alphaJ | alphaM,
+
+	'!':  alphaB | alphaC,
+	'"':  alphaF | alphaI,
+	'#':  alphaB | alphaC | alphaD | alphaG | alphaI | alphaL,
+	'$':  alphaA | alphaC | alphaD | alphaF | alphaG | alphaI | alphaL,
+	'%':  alphaA1 | alphaC | alphaD2 | alphaF | alphaG | alphaI | alphaJ | alphaL | alphaM,
+	'\'': alphaI,
+	'(':  alphaJ | alphaK,
+	')':  alphaH | alphaM,
+	'*':  alphaG | alphaH | alphaI | alphaJ | alphaK | alphaL | alphaM,
+	'+':  alphaG | alphaI | alphaL,
+	',':  alphaM,
+	'-':  alphaG,
+	'.':  alphaD1,
+	'/':  alphaJ | alphaM,
+	'<':  alphaJ | alphaK,
+	'=':  alphaD | alphaG,
+	'>':  alphaH | alphaM,
+	'?':  alphaA | alphaB | alphaG2 | alphaL,
+	'@':  alphaA | alphaB | alphaD | alphaE | alphaF | alphaG2 | alphaI,
+	'[':  alphaA1 | alphaD1 | alphaE | alphaF,
+	'\\': alphaH | alphaK,
+	']':  alphaA2 | alphaB | alphaC | alphaD2,
+	'^':  alphaK | alphaM,
+	'_':  alphaD,
+	'`':  alphaH,
+	'|':  alphaI | alphaL,
+}
+
+type alphaRenderer struct {
+	alpha          *AlphaWidget
+	segmentObjects []fyne.CanvasObject
+}
+
+func (r *alphaRenderer) Destroy() {
+}
+
+func (r *alphaRenderer) Layout(fyne.Size) {
+}
+
+func (r *alphaRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(
+		r.alpha.size.Width+theme.Padding()*2+2*r.alpha.hexOffset,
+		r.alpha.size.Height+theme.Padding()*2,
+	)
+}
+
+func (r *alphaRenderer) Objects() []fyne.CanvasObject {
+	return r.segmentObjects
+}
+
+func (r *alphaRenderer) Refresh() {
+	a := r.alpha
+	segmentWidth := 0.2 * a.size.Width
+	vLength := (9.14 / (2 * 14)) * a.size.Height
+	hLength := (4.8 / 7.5) * a.size.Width
+
+	// point returns the position of a point on the unslanted grid, leaning it right above the middle and left below
+	centerX := a.hexOffset + a.size.Width/2
+	point := func(x, y float32) fyne.Position {
+		return fyne.NewPos(centerX+x*hLength/2+a.hexOffset*(1-y/vLength), y)
+	}
+	left, right, top, middle, bottom := float32(-1), float32(1), float32(0), vLength, 2*vLength
+	ends := [16][2]fyne.Position{
+		{point(left, top), point(0, top)},            // a1
+		{point(0, top), point(right, top)},           // a2
+		{point(right, top), point(right, middle)},    // b
+		{point(right, middle), point(right, bottom)}, // c
+		{point(right, bottom), point(0, bottom)},     // d2
+		{point(0, bottom), point(left, bottom)},      // d1
+		{point(left, bottom), point(left, middle)},   // e
+		{point(left, middle), point(left, top)},      // f
+		{point(left, middle), point(0, middle)},      // g1
+		{point(0, middle), point(right, middle)},     // g2
+		{point(left, top), point(0, middle)},         // h
+		{point(0, top), point(0, middle)},            // i
+		{point(right, top), point(0, middle)},        // j
+		{point(0, middle), point(right, bottom)},     // k
+		{point(0, middle), point(0, bottom)},         // l
+		{point(0, middle), point(left, bottom)},      // m
+	}
+
+	fourteen := a.count == FourteenSegments
+	for i, o := range r.segmentObjects {
+		line := o.(*canvas.Line)
+		from, to := ends[i][0], ends[i][1]
+		if i >= 10 { // keep the inner segments apart where they meet
+			from, to = shortenLine(from, to, segmentWidth/2)
+		}
+		line.StrokeWidth = segmentWidth / 3
+		line.StrokeColor = a.getSegmentColor(uint16(1) << uint(i))
+		line.Hidden = false
+
+		if fourteen {
+			switch uint16(1) << uint(i) {
+			case alphaA1:
+				to = ends[1][1]
+				line.StrokeColor = a.getSegmentColor(alphaA)
+			case alphaD1:
+				from = ends[4][0]
+				line.StrokeColor = a.getSegmentColor(alphaD)
+			case alphaA2, alphaD2:
+				line.Hidden = true
+			}
+		}
+		setLineEndpoints(line, from, to)
+		canvas.Refresh(line)
+	}
+}
+
+// AlphaWidget represents a 14 or 16-segment alphanumeric display. The segments
+// of the display are mapped active-low onto 16 state bits, in the order a1, a2,
+// b, c, d2, d1, e, f, g1, g2, h, i, j, k, l, m from the least significant bit.
+// A 14-segment display draws a1 and a2 as one segment, which is on if either is,
+// and likewise for d1 and d2.
+//
+//      a1   a2
+//     ---- ----
+//    |\   |   /|
+//  f | h  i  j | b
+//    |  \ | /  |
+//  g1 ---- ---- g2
+//    |  / | \  |
+//  e | m  l  k | c
+//    |/   |   \|
+//     ---- ----
+//      d1   d2
+type AlphaWidget struct {
+	widget.BaseWidget
+	segments uint16
+	count    AlphaSegments
+
+	// size of the alpha widget
+	size fyne.Size
+
+	// slant angle
+	hexOffset float32
+
+	// color when a segment is on, or nil to follow the theme
+	onColor color.Color
+
+	// color when a segment is off, or nil to follow the theme
+	offColor color.Color
+}
+
+// NewAlphaWidget instantiates a new widget instance with the given number of
+// segments, with all of the segments disabled.
+func NewAlphaWidget(count AlphaSegments) *AlphaWidget {
+	if count != FourteenSegments {
+		count = SixteenSegments
+	}
+	a := &AlphaWidget{
+		segments:  alphaBlank,
+		count:     count,
+		size:      fyne.NewSize(defaultHexWidth, defaultHexHeight),
+		hexOffset: defaultHexOffset,
+	}
+
+	a.ExtendBaseWidget(a)
+	return a
+}
+
+// CreateRenderer implements fyne.Widget
+func (a *AlphaWidget) CreateRenderer() fyne.WidgetRenderer {
+	r := &alphaRenderer{alpha: a, segmentObjects: make([]fyne.CanvasObject, 16)}
+	for i := range r.segmentObjects {
+		r.segmentObjects[i] = canvas.NewLine(a.getSegmentColor(0xffff))
+	}
+
+	r.Refresh()
+	return r
+}
+
+// Set updates the widget to show an ASCII character. Lower case letters are
+// shown in upper case, and characters that cannot be shown leave it blank.
+func (a *AlphaWidget) Set(r rune) {
+	a.UpdateSegments(alphaSegments(r))
+}
+
+// SetOffColor changes the color that segments are shown as when they are
+// inactive/off. Passing nil returns to a faint version of the on color.
+func (a *AlphaWidget) SetOffColor(c color.Color) {
+	a.offColor = c
+	a.Refresh()
+}
+
+// SetOnColor changes the color that segments are shown as when they are
+// active/on. Passing nil returns to the primary color of the theme.
+func (a *AlphaWidget) SetOnColor(c color.Color) {
+	a.onColor = c
+	a.Refresh()
+}
+
+// SetSize changes the size of the alpha widget.
+func (a *AlphaWidget) SetSize(s fyne.Size) {
+	a.size = s
+	a.Refresh()
+}
+
+// SetSlant changes the amount of "slant", see HexWidget.SetSlant.
+func (a *AlphaWidget) SetSlant(s float32) {
+	a.hexOffset = s
+	a.Refresh()
+}
+
+// UpdateSegments changes the state of the segments and causes the widget to
+// refresh so the changes are visible to the user. Segments values are packed
+// into the 16-bit segments integer, see the documentation for AlphaWidget for
+// more information on the appropriate packing.
+func (a *AlphaWidget) UpdateSegments(segments uint16) {
+	a.segments = segments
+	a.Refresh()
+}
+
+// getSegmentColor returns the on color if any of the segments in the mask are on.
+func (a *AlphaWidget) getSegmentColor(mask uint16) color.Color {
+	on, off := segmentColors(a.onColor, a.offColor)
+	if a.segments&mask != mask {
+		return on
+	}
+
+	return off
+}
+
+// alphaSegments returns the raw segment state that shows a character.
+func alphaSegments(r rune) uint16 {
+	if r >= 'a' && r <= 'z' {
+		r -= 'a' - 'A'
+	}
+	if r < 0 || int(r) >= len(alphaLookupTable) {
+		return alphaBlank
+	}
+	return ^alphaLookupTable[r]
+}
+
+// shortenLine moves both ends of a line towards each other by a distance.
+func shortenLine(from, to fyne.Position, distance float32) (fyne.Position, fyne.Position) {
+	dx, dy := to.X-from.X, to.Y-from.Y
+	length := float32(math.Hypot(float64(dx), float64(dy)))
+	if length <= 2*distance {
+		return from, to
+	}
+	step := fyne.NewPos(dx*distance/length, dy*distance/length)
+	return from.Add(step), to.Subtract(step)
+}
@@ -0,0 +1,65 @@
+package widget
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestAlphaWidget_Set(t *testing.T) {
+	a := NewAlphaWidget(SixteenSegments)
+	assert.Equal(t, alphaBlank, a.segments)
+
+	a.Set('T')
+	assert.Equal(t, ^(alphaA1 | alphaA2 | alphaI | alphaL), a.segments)
+	a.Set('t')
+	assert.Equal(t, ^(alphaA1 | alphaA2 | alphaI | alphaL), a.segments)
+
+	a.Set(' ')
+	assert.Equal(t, alphaBlank, a.segments)
+	a.Set('é') // outside ASCII
+	assert.Equal(t, alphaBlank, a.segments)
+
+	for r := '0'; r <= 'Z'; r++ {
+		if r <= '9' || r >= 'A' {
+			assert.NotZero(t, alphaLookupTable[r], "no segments for %c", r)
+		}
+	}
+}
+
+func TestAlphaWidget_UpdateSegments(t *testing.T) {
+	test.NewApp()
+
+	a := NewAlphaWidget(SixteenSegments)
+	r := test.WidgetRenderer(a).(*alphaRenderer)
+	on, off := segmentColors(nil, nil)
+	a.UpdateSegments(^alphaA1)
+	assert.Equal(t, on, r.segmentObjects[0].(*canvas.Line).StrokeColor)
+	assert.Equal(t, off, r.segmentObjects[1].(*canvas.Line).StrokeColor)
+	for _, o := range r.segmentObjects {
+		assert.True(t, o.Visible())
+	}
+}
+
+func TestAlphaWidget_FourteenSegments(t *testing.T) {
+	test.NewApp()
+
+	a := NewAlphaWidget(FourteenSegments)
+	r := test.WidgetRenderer(a).(*alphaRenderer)
+	on, off := segmentColors(nil, nil)
+	a.UpdateSegments(^alphaA2) // either half lights the whole segment
+	top := r.segmentObjects[0].(*canvas.Line)
+	assert.Equal(t, on, top.StrokeColor)
+	assert.False(t, r.segmentObjects[1].Visible())
+	assert.False(t, r.segmentObjects[4].Visible())
+	assert.Equal(t, r.segmentObjects[1].(*canvas.Line).Position2, top.Position2)
+
+	a.Set('L')
+	assert.Equal(t, off, top.StrokeColor)
+	assert.Equal(t, on, r.segmentObjects[5].(*canvas.Line).StrokeColor) // d1 draws the whole bottom
+
+	assert.Equal(t, SixteenSegments, NewAlphaWidget(7).count)
+}
@@ -0,0 +1,204 @@
+package widget
+
+import (
+	"image"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+)
+
+// AnimationFill defines how the frames of an animated widget are scaled into the space available.
+type AnimationFill int
+
+const (
+	// AnimationFillContain scales the frames to fit within the widget, keeping their aspect ratio.
+	// This is the default fill mode.
+	AnimationFillContain AnimationFill = iota
+	// AnimationFillStretch scales the frames to match the widget size, ignoring their aspect ratio.
+	AnimationFillStretch
+	// AnimationFillOriginal draws the frames at their pixel size, centred in the widget.
+	AnimationFillOriginal
+	// AnimationFillCover scales the frames to cover the widget, keeping their aspect ratio.
+	// Any part of the frame that does not fit will be cropped equally from both sides.
+	AnimationFillCover
+)
+
+// loopsForCount converts a gif style loop count into the number of times the frames should be played.
+// A count of -1 plays once, 0 loops forever (returned as -1) and any other value n plays n+1 times.
+func loopsForCount(count int) int {
+	switch count {
+	case -1: // don't loop
+		return 1
+	case 0: // loop forever
+		return -1
+	default:
+		return count + 1
+	}
+}
+
+// layoutAnimationFrame updates img to show frame using the fill mode inside an area of the given size.
+// The object is used to look up the canvas scale that original size images are drawn at.
+func layoutAnimationFrame(o fyne.CanvasObject, img *canvas.Image, frame image.Image, fill AnimationFill, size fyne.Size) {
+	img.Move(fyne.NewPos(0, 0))
+	img.Resize(size)
+	switch fill {
+	case AnimationFillStretch:
+		img.FillMode = canvas.ImageFillStretch
+		img.Image = frame
+	case AnimationFillOriginal:
+		img.FillMode = canvas.ImageFillStretch
+		img.Image = frame
+		if frame != nil {
+			pixels := pixelSize(o, frame.Bounds().Size())
+			img.Move(fyne.NewPos((size.Width-pixels.Width)/2, (size.Height-pixels.Height)/2))
+			img.Resize(pixels)
+		}
+	case AnimationFillCover:
+		img.FillMode = canvas.ImageFillStretch
+		img.Image = coverFrame(frame, size)
+	default:
+		img.FillMode = canvas.ImageFillContain
+		img.Image = frame
+	}
+	img.Refresh()
+}
+
+// coverFrame crops the frame to match the aspect ratio of size.
+// The returned image shares pixels with the frame so later drawing into it will still be visible.
+func coverFrame(frame image.Image, size fyne.Size) image.Image {
+	sub, ok := frame.(interface {
+		SubImage(image.Rectangle) image.Image
+	})
+	if !ok || size.Width <= 0 || size.Height <= 0 || frame.Bounds().Empty() {
+		return frame
+	}
+
+	b := frame.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if float32(w)*size.Height > float32(h)*size.Width { // frame is too wide
+		crop := int(float32(h) * size.Width / size.Height)
+		x := b.Min.X + (w-crop)/2
+		return sub.SubImage(image.Rect(x, b.Min.Y, x+crop, b.Max.Y))
+	}
+
+	crop := int(float32(w) * size.Height / size.Width)
+	y := b.Min.Y + (h-crop)/2
+	return sub.SubImage(image.Rect(b.Min.X, y, b.Max.X, y+crop))
+}
+
+// pixelSize converts a size in pixels to the canvas size that will draw it at 1:1 on the output device.
+func pixelSize(o fyne.CanvasObject, pixels image.Point) fyne.Size {
+	scale := float32(1)
+	if app := fyne.CurrentApp(); app != nil {
+		if c := app.Driver().CanvasForObject(o); c != nil {
+			scale = c.Scale()
+		}
+	}
+
+	return fyne.NewSize(float32(pixels.X)/scale, float32(pixels.Y)/scale)
+}
+
+// animatedWidget is a widget that shows its frames in a single image, as drawn by animationRenderer.
+type animatedWidget interface {
+	fyne.Widget
+	Stop()
+	layoutFrame()
+}
+
+// animationPlayer runs the playback loop shared by the animated widgets, which embed it.
+type animationPlayer struct {
+	remaining         int
+	stopping, running bool
+	runLock           sync.RWMutex
+}
+
+// play shows the frames in turn on a background goroutine, for the number of loops returned by loopsForCount or
+// until stop is called. Each frame is shown by calling showFrame with its index, which returns how long to wait
+// before showing the next. Playback pauses while the motion policy does not allow the object to animate.
+// Nothing happens if the animation is already running or there are no frames.
+func (a *animationPlayer) play(o fyne.CanvasObject, loops, frames int, showFrame func(int) time.Duration) {
+	a.runLock.Lock()
+	if a.running || frames == 0 {
+		a.runLock.Unlock()
+		return
+	}
+	a.running = true
+	a.stopping = false
+	a.remaining = loops
+	a.runLock.Unlock()
+
+	go func() {
+		for a.loopsRemaining() != 0 && !a.isStopping() {
+			for c := 0; c < frames; c++ {
+				if !waitForMotion(o, a.isStopping) {
+					break
+				}
+				time.Sleep(showFrame(c))
+			}
+
+			a.runLock.Lock()
+			if a.remaining > -1 { // don't underflow int
+				a.remaining--
+			}
+			a.runLock.Unlock()
+		}
+
+		a.runLock.Lock()
+		a.running = false
+		a.runLock.Unlock()
+	}()
+}
+
+// stop requests that the playback loop stops, leaving the last frame visible.
+func (a *animationPlayer) stop() {
+	a.runLock.Lock()
+	a.stopping = true
+	a.runLock.Unlock()
+}
+
+func (a *animationPlayer) isRunning() bool {
+	a.runLock.RLock()
+	defer a.runLock.RUnlock()
+	return a.running
+}
+
+func (a *animationPlayer) isStopping() bool {
+	a.runLock.RLock()
+	defer a.runLock.RUnlock()
+	return a.stopping
+}
+
+// loopsRemaining returns how many more times the frames will be played, or -1 if they loop forever.
+func (a *animationPlayer) loopsRemaining() int {
+	a.runLock.RLock()
+	defer a.runLock.RUnlock()
+	return a.remaining
+}
+
+// animationRenderer draws an animated widget, which shows each frame by updating the image dst.
+type animationRenderer struct {
+	widget animatedWidget
+	dst    *canvas.Image
+}
+
+func (a *animationRenderer) Destroy() {
+	a.widget.Stop()
+}
+
+func (a *animationRenderer) Layout(size fyne.Size) {
+	a.widget.layoutFrame()
+}
+
+func (a *animationRenderer) MinSize() fyne.Size {
+	return a.widget.MinSize()
+}
+
+func (a *animationRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{a.dst}
+}
+
+func (a *animationRenderer) Refresh() {
+	a.dst.Refresh()
+}
@@ -0,0 +1,392 @@
+package widget
+
+import (
+	"image/color"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	// dotMatrixCharWidth and dotMatrixCharHeight are the size of a character of the font, in dots.
+	dotMatrixCharWidth  = 5
+	dotMatrixCharHeight = 7
+
+	defaultDotSize     float32 = 6
+	defaultScrollSpeed float32 = 10
+)
+
+// dotMatrixFont is a 5x7 bitmap font for the printable ASCII characters, starting at the space.
+// Each character is five columns from left to right, with the top row in the least significant bit.
+var dotMatrixFont = [95][dotMatrixCharWidth]uint8{
+	{0x00, 0x00, 0x00, 0x00, 0x00}, // space
+	{0x00, 0x00, 0x5f, 0x00, 0x00}, // !
+	{0x00, 0x07, 0x00, 0x07, 0x00}, // "
+	{0x14, 0x7f, 0x14, 0x7f, 0x14}, // #
+	{0x24, 0x2a, 0x7f, 0x2a, 0x12}, // $
+	{0x23, 0x13, 0x08, 0x64, 0x62}, // %
+	{0x36, 0x49, 0x55, 0x22, 0x50}, // &
+	{0x00, 0x05, 0x03, 0x00, 0x00}, // '
+	{0x00, 0x1c, 0x22, 0x41, 0x00}, // (
+	{0x00, 0x41, 0x22, 0x1c, 0x00}, // )
+	{0x08, 0x2a, 0x1c, 0x2a, 0x08}, // *
+	{0x08, 0x08, 0x3e, 0x08, 0x08}, // +
+	{0x00, 0x50, 0x30, 0x00, 0x00}, // ,
+	{0x08, 0x08, 0x08, 0x08, 0x08}, // -
+	{0x00, 0x60, 0x60, 0x00, 0x00}, // .
+	{0x20, 0x10, 0x08, 0x04, 0x02}, // /
+	{0x3e, 0x51, 0x49, 0x45, 0x3e}, // 0
+	{0x00, 0x42, 0x7f, 0x40, 0x00}, // 1
+	{0x42, 0x61, 0x51, 0x49, 0x46}, // 2
+	{0x21, 0x41, 0x45, 0x4b, 0x31}, // 3
+	{0x18, 0x14, 0x12, 0x7f, 0x10}, // 4
+	{0x27, 0x45, 0x45, 0x45, 0x39}, // 5
+	{0x3c, 0x4a, 0x49, 0x49, 0x30}, // 6
+	{0x01, 0x71, 0x09, 0x05, 0x03}, // 7
+	{0x36, 0x49, 0x49, 0x49, 0x36}, // 8
+	{0x06, 0x49, 0x49, 0x29, 0x1e}, // 9
+	{0x00, 0x36, 0x36, 0x00, 0x00}, // :
+	{0x00, 0x56, 0x36, 0x00, 0x00}, // ;
+	{0x08, 0x14, 0x22, 0x41, 0x00}, // <
+	{0x14, 0x14, 0x14, 0x14, 0x14}, // =
+	{0x00, 0x41, 0x22, 0x14, 0x08}, // >
+	{0x02, 0x01, 0x51, 0x09, 0x06}, // ?
+	{0x32, 0x49, 0x79, 0x41, 0x3e}, // @
+	{0x7e, 0x11, 0x11, 0x11, 0x7e}, // A
+	{0x7f, 0x49, 0x49, 0x49, 0x36}, // B
+	{0x3e, 0x41, 0x41, 0x41, 0x22}, // C
+	{0x7f, 0x41, 0x41, 0x22, 0x1c}, // D
+	{0x7f, 0x49, 0x49, 0x49, 0x41}, // E
+	{0x7f, 0x09, 0x09, 0x09, 0x01}, // F
+	{0x3e, 0x41, 0x49, 0x49, 0x7a}, // G
+	{0x7f, 0x08, 0x08, 0x08, 0x7f}, // H
+	{0x00, 0x41, 0x7f, 0x41, 0x00}, // I
+	{0x20, 0x40, 0x41, 0x3f, 0x01}, // J
+	{0x7f, 0x08, 0x14, 0x22, 0x41}, // K
+	{0x7f, 0x40, 0x40, 0x40, 0x40}, // L
+	{0x7f, 0x02, 0x0c, 0x02, 0x7f}, // M
+	{0x7f, 0x04, 0x08, 0x10, 0x7f}, // N
+	{0x3e, 0x41, 0x41, 0x41, 0x3e}, // O
+	{0x7f, 0x09, 0x09, 0x09, 0x06}, // P
+	{0x3e, 0x41, 0x51, 0x21, 0x5e}, // Q
+	{0x7f, 0x09, 0x19, 0x29, 0x46}, // R
+	{0x46, 0x49, 0x49, 0x49, 0x31}, // S
+	{0x01, 0x01, 0x7f, 0x01, 0x01}, // T
+	{0x3f, 0x40, 0x40, 0x40, 0x3f}, // U
+	{0x1f, 0x20, 0x40, 0x20, 0x1f}, // V
+	{0x3f, 0x40, 0x38, 0x40, 0x3f}, // W
+	{0x63, 0x14, 0x08, 0x14, 0x63}, // X
+	{0x07, 0x08, 0x70, 0x08, 0x07}, // Y
+	{0x61, 0x51, 0x49, 0x45, 0x43}, // Z
+	{0x00, 0x7f, 0x41, 0x41, 0x00}, // [
+	{0x02, 0x04, 0x08, 0x10, 0x20}, // \
+	{0x00, 0x41, 0x41, 0x7f, 0x00}, // ]
+	{0x04, 0x02, 0x01, 0x02, 0x04}, // ^
+	{0x40, 0x40, 0x40, 0x40, 0x40}, // _
+	{0x00, 0x01, 0x02, 0x04, 0x00}, // `
+	{0x20, 0x54, 0x54, 0x54, 0x78}, // a
+	{0x7f, 0x48, 0x44, 0x44, 0x38}, // b
+	{0x38, 0x44, 0x44, 0x44, 0x20}, // c
+	{0x38, 0x44, 0x44, 0x48, 0x7f}, // d
+	{0x38, 0x54, 0x54, 0x54, 0x18}, // e
+	{0x08, 0x7e, 0x09, 0x01, 0x02}, // f
+	{0x0c, 0x52, 0x52, 0x52, 0x3e}, // g
+	{0x7f, 0x08, 0x04, 0x04, 0x78}, // h
+	{0x00, 0x44, 0x7d, 0x40, 0x00}, // i
+	{0x20, 0x40, 0x44, 0x3d, 0x00}, // j
+	{0x7f, 0x10, 0x28, 0x44, 0x00}, // k
+	{0x00, 0x41, 0x7f, 0x40, 0x00}, // l
+	{0x7c, 0x04, 0x18, 0x04, 0x78}, // m
+	{0x7c, 0x08, 0x04, 0x04, 0x78}, // n
+	{0x38, 0x44, 0x44, 0x44, 0x38}, // o
+	{0x7c, 0x14, 0x14, 0x14, 0x08}, // p
+	{0x08, 0x14, 0x14, 0x18, 0x7c}, // q
+	{0x7c, 0x08, 0x04, 0x04, 0x08}, // r
+	{0x48, 0x54, 0x54, 0x54, 0x20}, // s
+	{0x04, 0x3f, 0x44, 0x40, 0x20}, // t
+	{0x3c, 0x40, 0x40, 0x20, 0x7c}, // u
+	{0x1c, 0x20, 0x40, 0x20, 0x1c}, // v
+	{0x3c, 0x40, 0x30, 0x40, 0x3c}, // w
+	{0x44, 0x28, 0x10, 0x28, 0x44}, // x
+	{0x0c, 0x50, 0x50, 0x50, 0x3c}, // y
+	{0x44, 0x64, 0x54, 0x4c, 0x44}, // z
+	{0x00, 0x08, 0x36, 0x41, 0x00}, // {
+	{0x00, 0x00, 0x7f, 0x00, 0x00}, // |
+	{0x00, 0x41, 0x36, 0x08, 0x00}, // }
+	{0x08, 0x04, 0x08, 0x10, 0x08}, // ~
+}
+
+// DotMatrixDisplay simulates an LED dot-matrix display made of cells of 5x7 dots, with a column of dots
+// between the cells left dark. It can show text using a built-in font, either still or scrolling from
+// right to left, or each dot can be controlled directly.
+type DotMatrixDisplay struct {
+	widget.BaseWidget
+
+	cells   int
+	columns []uint8 // the dots of each column, with the top row in the least significant bit
+	text    []uint8 // the columns of the text, without the gap for the display
+
+	dotSize  float32
+	speed    float32
+	offset   int
+	onColor  color.Color
+	offColor color.Color
+	lock     sync.RWMutex
+
+	animationPlayer
+}
+
+// NewDotMatrixDisplay creates a display with the given number of character cells, with all of the dots off.
+func NewDotMatrixDisplay(cells int) *DotMatrixDisplay {
+	if cells < 1 {
+		cells = 1
+	}
+	d := &DotMatrixDisplay{
+		cells:   cells,
+		columns: make([]uint8, cells*(dotMatrixCharWidth+1)-1),
+		dotSize: defaultDotSize,
+		speed:   defaultScrollSpeed,
+	}
+	d.ExtendBaseWidget(d)
+	return d
+}
+
+// Clear turns off all of the dots.
+func (d *DotMatrixDisplay) Clear() {
+	d.lock.Lock()
+	d.text = nil
+	for x := range d.columns {
+		d.columns[x] = 0
+	}
+	d.lock.Unlock()
+	d.Refresh()
+}
+
+// CreateRenderer implements fyne.Widget
+func (d *DotMatrixDisplay) CreateRenderer() fyne.WidgetRenderer {
+	r := &dotMatrixRenderer{display: d}
+	width := len(d.columns)
+	for y := 0; y < dotMatrixCharHeight; y++ {
+		for x := 0; x < width; x++ {
+			r.dots = append(r.dots, canvas.NewCircle(color.Transparent))
+		}
+	}
+	r.Refresh()
+	return r
+}
+
+// Dimensions returns the number of dots across and down the display.
+func (d *DotMatrixDisplay) Dimensions() (int, int) {
+	return len(d.columns), dotMatrixCharHeight
+}
+
+// Dot returns true if the dot at column x and row y is on.
+func (d *DotMatrixDisplay) Dot(x, y int) bool {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	if x < 0 || x >= len(d.columns) || y < 0 || y >= dotMatrixCharHeight {
+		return false
+	}
+	return d.columns[x]&(1<<uint(y)) != 0
+}
+
+// SetDot turns the dot at column x and row y on or off. Dots outside the display are ignored.
+// While text is scrolling the dots are replaced as it moves.
+func (d *DotMatrixDisplay) SetDot(x, y int, on bool) {
+	d.lock.Lock()
+	if x < 0 || x >= len(d.columns) || y < 0 || y >= dotMatrixCharHeight {
+		d.lock.Unlock()
+		return
+	}
+	if on {
+		d.columns[x] |= 1 << uint(y)
+	} else {
+		d.columns[x] &^= 1 << uint(y)
+	}
+	d.lock.Unlock()
+	d.Refresh()
+}
+
+// SetDotSize changes the distance between the centres of neighbouring dots.
+func (d *DotMatrixDisplay) SetDotSize(size float32) {
+	d.lock.Lock()
+	d.dotSize = size
+	d.lock.Unlock()
+	d.Refresh()
+}
+
+// SetOffColor changes the color that dots are shown as when they are inactive/off.
+// Passing nil returns to a faint version of the on color.
+func (d *DotMatrixDisplay) SetOffColor(c color.Color) {
+	d.lock.Lock()
+	d.offColor = c
+	d.lock.Unlock()
+	d.Refresh()
+}
+
+// SetOnColor changes the color that dots are shown as when they are active/on.
+// Passing nil returns to the primary color of the theme.
+func (d *DotMatrixDisplay) SetOnColor(c color.Color) {
+	d.lock.Lock()
+	d.onColor = c
+	d.lock.Unlock()
+	d.Refresh()
+}
+
+// SetScrollSpeed changes how many columns of dots the text moves each second while it is scrolling.
+// Speeds of 0 or less are ignored.
+func (d *DotMatrixDisplay) SetScrollSpeed(columnsPerSecond float32) {
+	if columnsPerSecond <= 0 {
+		return
+	}
+	d.lock.Lock()
+	d.speed = columnsPerSecond
+	d.lock.Unlock()
+}
+
+// SetText shows text using the built-in font, starting at the left of the display. Each character fills a cell
+// and text that is too long is cut short, unless it is scrolling. Characters outside printable ASCII are blank.
+// If the text is scrolling it starts again from the right of the display.
+func (d *DotMatrixDisplay) SetText(text string) {
+	d.lock.Lock()
+	d.text = d.text[:0]
+	for i, r := range []rune(text) {
+		if i > 0 {
+			d.text = append(d.text, 0)
+		}
+		if r >= ' ' && int(r-' ') < len(dotMatrixFont) {
+			d.text = append(d.text, dotMatrixFont[r-' '][:]...)
+		} else {
+			d.text = append(d.text, make([]uint8, dotMatrixCharWidth)...)
+		}
+	}
+	d.offset = 0
+	if !d.isRunning() {
+		d.offset = len(d.columns)
+	}
+	d.showText()
+	d.lock.Unlock()
+	d.Refresh()
+}
+
+// StartScrolling moves the text from right to left across the display, repeating once it has scrolled out of view.
+// Scrolling will pause while the CurrentMotionPolicy does not allow animations to play.
+func (d *DotMatrixDisplay) StartScrolling() {
+	started := false
+	d.play(d, -1, 1, func(int) time.Duration {
+		d.lock.Lock()
+		if !started { // the text starts out of view on the right
+			d.offset = 0
+			started = true
+		}
+		d.lock.Unlock()
+		d.scroll()
+
+		d.lock.RLock()
+		defer d.lock.RUnlock()
+		return time.Duration(float32(time.Second) / d.speed)
+	})
+}
+
+// StopScrolling requests that the text stops moving, leaving it where it is.
+func (d *DotMatrixDisplay) StopScrolling() {
+	d.stop()
+}
+
+// UpdateColumns sets the dots of each column from the left of the display, with the top row in the least
+// significant bit. Columns beyond the display are ignored.
+func (d *DotMatrixDisplay) UpdateColumns(columns []uint8) {
+	d.lock.Lock()
+	copy(d.columns, columns)
+	d.lock.Unlock()
+	d.Refresh()
+}
+
+// scroll moves the text one column to the left, starting again once it has moved out of view.
+func (d *DotMatrixDisplay) scroll() {
+	d.lock.Lock()
+	d.offset++
+	if d.offset > len(d.text)+len(d.columns) {
+		d.offset = 1
+	}
+	d.showText()
+	d.lock.Unlock()
+	d.Refresh()
+}
+
+// showText copies the text to the display columns, with the first column of the text at offset columns from
+// the right of the display. The lock must be held.
+func (d *DotMatrixDisplay) showText() {
+	start := d.offset - len(d.columns)
+	for x := range d.columns {
+		d.columns[x] = 0
+		if i := start + x; i >= 0 && i < len(d.text) {
+			d.columns[x] = d.text[i]
+		}
+	}
+}
+
+type dotMatrixRenderer struct {
+	display *DotMatrixDisplay
+	dots    []*canvas.Circle
+	objects []fyne.CanvasObject
+}
+
+func (r *dotMatrixRenderer) Destroy() {
+	r.display.StopScrolling()
+}
+
+func (r *dotMatrixRenderer) Layout(fyne.Size) {
+	r.display.lock.RLock()
+	defer r.display.lock.RUnlock()
+	width, pitch := len(r.display.columns), r.display.dotSize
+	dot := pitch * 0.8
+	for i, c := range r.dots {
+		x, y := i%width, i/width
+		c.Move(fyne.NewPos(theme.Padding()+float32(x)*pitch, theme.Padding()+float32(y)*pitch))
+		c.Resize(fyne.NewSize(dot, dot))
+	}
+}
+
+func (r *dotMatrixRenderer) MinSize() fyne.Size {
+	r.display.lock.RLock()
+	defer r.display.lock.RUnlock()
+	pitch := r.display.dotSize
+	return fyne.NewSize(
+		float32(len(r.display.columns))*pitch+theme.Padding()*2,
+		dotMatrixCharHeight*pitch+theme.Padding()*2,
+	)
+}
+
+func (r *dotMatrixRenderer) Objects() []fyne.CanvasObject {
+	if r.objects == nil {
+		r.objects = make([]fyne.CanvasObject, len(r.dots))
+		for i, c := range r.dots {
+			r.objects[i] = c
+		}
+	}
+	return r.objects
+}
+
+func (r *dotMatrixRenderer) Refresh() {
+	r.Layout(r.display.Size())
+
+	r.display.lock.RLock()
+	width := len(r.display.columns)
+	on, off := segmentColors(r.display.onColor, r.display.offColor)
+	for i, c := range r.dots {
+		x, y := i%width, i/width
+		c.FillColor = off
+		if r.display.columns[x]&(1<<uint(y)) != 0 {
+			c.FillColor = on
+		}
+	}
+	r.display.lock.RUnlock()
+	for _, c := range r.dots {
+		canvas.Refresh(c)
+	}
+}
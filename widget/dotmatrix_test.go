@@ -0,0 +1,136 @@
+package widget
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+)
+
+func TestDotMatrixDisplay_SetText(t *testing.T) {
+	d := NewDotMatrixDisplay(2)
+	width, height := d.Dimensions()
+	assert.Equal(t, 11, width)
+	assert.Equal(t, 7, height)
+
+	d.SetText("I-")
+	// the I is a vertical line in the middle of the first cell, with serifs
+	for y := 0; y < height; y++ {
+		assert.True(t, d.Dot(2, y))
+	}
+	assert.True(t, d.Dot(1, 0))
+	assert.False(t, d.Dot(1, 3))
+	assert.Equal(t, uint8(0), d.columns[5]) // the gap between cells
+	assert.Equal(t, dotMatrixFont['-'-' '][:], d.columns[6:])
+
+	d.SetText("IIII") // cut short
+	assert.Equal(t, d.columns[0:5], d.columns[6:11])
+
+	d.SetText("é")
+	assert.Equal(t, make([]uint8, width), d.columns)
+}
+
+func TestDotMatrixDisplay_Dots(t *testing.T) {
+	test.NewApp()
+
+	d := NewDotMatrixDisplay(1)
+	r := test.WidgetRenderer(d).(*dotMatrixRenderer)
+	assert.Len(t, r.Objects(), 35)
+
+	d.SetDot(4, 6, true)
+	d.SetDot(5, 0, true) // outside
+	assert.True(t, d.Dot(4, 6))
+	assert.False(t, d.Dot(5, 0))
+	on, off := segmentColors(nil, nil)
+	assert.Equal(t, on, r.dots[6*5+4].FillColor)
+	assert.Equal(t, off, r.dots[0].FillColor)
+
+	d.SetDot(4, 6, false)
+	assert.False(t, d.Dot(4, 6))
+
+	d.UpdateColumns([]uint8{0x7f, 0, 0, 0, 0, 0xff})
+	assert.True(t, d.Dot(0, 6))
+	d.Clear()
+	assert.False(t, d.Dot(0, 6))
+
+	d.SetDotSize(10)
+	assert.Equal(t, fyne.NewSize(50+2*theme.Padding(), 70+2*theme.Padding()), d.MinSize())
+	assert.Equal(t, fyne.NewPos(14, 24), r.dots[2*5+1].Position())
+}
+
+func TestDotMatrixDisplay_Scroll(t *testing.T) {
+	d := NewDotMatrixDisplay(1)
+	d.StartScrolling()
+	d.StopScrolling()
+	assert.Eventually(t, func() bool {
+		return !d.isRunning()
+	}, time.Second, time.Millisecond)
+
+	d.running = true // scroll by hand
+	d.SetText("-")
+	assert.Equal(t, make([]uint8, 5), d.columns) // starts out of view on the right
+	d.scroll()
+	assert.Equal(t, []uint8{0, 0, 0, 0, 0x08}, d.columns)
+	for i := 0; i < 4; i++ {
+		d.scroll()
+	}
+	assert.Equal(t, dotMatrixFont['-'-' '][:], d.columns)
+	for i := 0; i < 5; i++ {
+		d.scroll()
+	}
+	assert.Equal(t, make([]uint8, 5), d.columns)
+	d.scroll() // starts again
+	assert.Equal(t, []uint8{0, 0, 0, 0, 0x08}, d.columns)
+	d.running = false
+}
+
+func TestDotMatrixDisplay_StartScrolling(t *testing.T) {
+	SetMotionPolicy(MotionFull)
+	defer SetMotionPolicy(MotionAuto)
+
+	d := NewDotMatrixDisplay(1)
+	d.SetScrollSpeed(1000)
+	d.SetScrollSpeed(0) // ignored
+	d.SetText("-")
+	d.StartScrolling()
+	assert.True(t, d.isRunning())
+	assert.Eventually(t, func() bool {
+		return d.Dot(0, 3)
+	}, time.Second, time.Millisecond)
+	d.StopScrolling()
+	assert.Eventually(t, func() bool {
+		return !d.isRunning()
+	}, time.Second, time.Millisecond)
+}
+
+func TestDotMatrixDisplay_StartScrolling_Once(t *testing.T) {
+	SetMotionPolicy(MotionFull)
+	defer SetMotionPolicy(MotionAuto)
+
+	d := NewDotMatrixDisplay(1)
+	d.SetScrollSpeed(1)
+	d.SetText("-")
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.StartScrolling()
+		}()
+	}
+	wg.Wait()
+	time.Sleep(100 * time.Millisecond)
+	d.lock.RLock()
+	assert.Equal(t, 1, d.offset) // scrolled by a single loop
+	d.lock.RUnlock()
+
+	test.WidgetRenderer(d).Destroy()
+	assert.Eventually(t, func() bool {
+		return !d.isRunning()
+	}, 2*time.Second, time.Millisecond)
+}
@@ -2,6 +2,9 @@ package widget
 
 import (
 	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -10,143 +13,627 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
+// loadingNodeSuffix is added to the ID of a branch to create the placeholder shown while it is listed.
+const loadingNodeSuffix = "\x00loading"
+
+// maxListWorkers limits how many directories can be listed in the background at the same time.
+const maxListWorkers = 4
+
+// loadRefreshDelay gathers branches that finish loading close together into a single refresh of the tree.
+const loadRefreshDelay = 10 * time.Millisecond
+
 // FileTree extends widget.Tree to display a file system hierarchy.
+// Directories are listed in the background, showing a loading placeholder until their contents are known.
 type FileTree struct {
 	widget.Tree
 	Filter storage.FileFilter
-	Sorter func(fyne.URI, fyne.URI) bool
+	// FilterLeavesOnly applies the Filter to files only, so that all folders are shown.
+	FilterLeavesOnly bool
+	Sorter           func(fyne.URI, fyne.URI) bool
+	// Decorator adds badges, colours and descriptions to the nodes, it may be nil.
+	Decorator FileDecorator
+	// IconProvider chooses the icon of each node, the standard folder and file icons are used if it is nil.
+	IconProvider FileIconProvider
+	// Label returns the text shown for a URI, such as a friendly name for the root.
+	// If it is nil or returns an empty string the name of the URI is shown, or the full URI for the root.
+	Label func(fyne.URI) string
+	// OnSelectionChanged is called with the selected URIs each time the selection changes.
+	OnSelectionChanged func([]fyne.URI)
+	// OnSearchChanged is called as matches are found by Search, and once more when it has finished.
+	OnSearchChanged func(matches []fyne.URI, finished bool)
+	// OnError is called when a branch cannot be listed, it is shown as an error node that can be tapped to retry.
+	// If it is not set the errors are logged.
+	OnError func(branch fyne.URI, err error)
+	// OnDropped is called when URIs are dragged on to the tree, with the folder they were dropped in.
+	// If it is not set and operations are enabled the URIs are moved to that folder.
+	OnDropped func(uris []fyne.URI, folder fyne.URI)
+	// OnBranchOpened is called when a branch is opened. It replaces the field of widget.Tree, which is used
+	// by the FileTree itself.
+	OnBranchOpened func(uid widget.TreeNodeID)
+	// OnBranchClosed is called when a branch is closed. It replaces the field of widget.Tree, which is used
+	// by the FileTree itself.
+	OnBranchClosed func(uid widget.TreeNodeID)
+	// OnSelected is called when a node becomes the current node of the selection. It replaces the field of
+	// widget.Tree, which is used by the FileTree itself. OnSelectionChanged reports the whole selection.
+	OnSelected func(uid widget.TreeNodeID)
+
+	listableCache    *lruCache
+	uriCache         *lruCache
+	childCache       map[widget.TreeNodeID][]widget.TreeNodeID
+	listingLimit     int                                 // how many branches keep their children, those that are shown are always kept
+	listingEvictions uint64                              // listings discarded to stay within the limit
+	loading          map[widget.TreeNodeID]chan struct{} // closed to cancel the load
+	revealing        *fileTreeReveal
+	background       int // loads, refreshes and timers that have not finished
+	errors           map[widget.TreeNodeID]error
+	cacheLock        sync.Mutex
+	workers          chan struct{}
+
+	refreshLock    sync.Mutex
+	refreshPending bool
+	rendering      int        // calls to the renderer in progress, which background refreshes wait for
+	rendered       *sync.Cond // signalled when rendering returns to zero
+	updateLock     sync.Mutex // held while refreshing from the background, or restoring a state, so they don't overlap
+
+	nodes      []*fileTreeNode
+	operations *FileTreeOperations
+	table      *FileTreeTable
+
+	selection     []widget.TreeNodeID
+	anchor        widget.TreeNodeID
+	cursor        widget.TreeNodeID
+	shift         bool
+	selectionLock sync.RWMutex
+
+	search     *fileTreeSearch
+	typed      string
+	typedAt    time.Time
+	searchLock sync.Mutex
+
+	watcher     FileWatcher
+	watcherDone chan struct{}
+	watched     map[widget.TreeNodeID]fyne.URI
+
+	scroller        *container.Scroll
+	opened          map[widget.TreeNodeID]bool // branches that have been opened, IsBranchOpen says if they still are
+	persistence     *fileTreePersistence
+	restoreOffset   float32
+	restoringOffset bool
+	stateLock       sync.Mutex
+
+	rootNames     map[widget.TreeNodeID]string
+	workspaceName string
 
-	listableCache map[widget.TreeNodeID]fyne.ListableURI
-	uriCache      map[widget.TreeNodeID]fyne.URI
+	drag        *fileTreeDrag
+	dropTargets []FileDropTarget
+	dropFolder  widget.TreeNodeID
+	hovered     widget.TreeNodeID
+	hoverTimer  *time.Timer
+	dragLock    sync.Mutex
 }
 
 // NewFileTree creates a new FileTree from the given root URI.
 func NewFileTree(root fyne.URI) *FileTree {
+	return newFileTree(root.String())
+}
+
+func newFileTree(root widget.TreeNodeID) *FileTree {
 	tree := &FileTree{
 		Tree: widget.Tree{
-			Root: root.String(),
-			CreateNode: func(branch bool) fyne.CanvasObject {
-				var icon fyne.CanvasObject
-				if branch {
-					icon = widget.NewIcon(nil)
-				} else {
-					icon = widget.NewFileIcon(nil)
-				}
-				return container.NewBorder(nil, nil, icon, nil, widget.NewLabel("Template Object"))
-			},
+			Root: root,
 		},
-		listableCache: make(map[widget.TreeNodeID]fyne.ListableURI),
-		uriCache:      make(map[widget.TreeNodeID]fyne.URI),
+		listableCache: newLRUCache(DefaultFileTreeCacheSize),
+		uriCache:      newLRUCache(DefaultFileTreeCacheSize),
+		childCache:    make(map[widget.TreeNodeID][]widget.TreeNodeID),
+		listingLimit:  DefaultFileTreeCacheSize,
+		loading:       make(map[widget.TreeNodeID]chan struct{}),
+		errors:        make(map[widget.TreeNodeID]error),
+		workers:       make(chan struct{}, maxListWorkers),
+		opened:        make(map[widget.TreeNodeID]bool),
 	}
-	tree.IsBranch = func(id widget.TreeNodeID) bool {
-		_, err := tree.toListable(id)
-		return err == nil
+	tree.Tree.OnBranchOpened = tree.branchOpened
+	tree.Tree.OnBranchClosed = tree.branchClosed
+	tree.Tree.OnSelected = tree.treeSelected
+	tree.CreateNode = func(branch bool) fyne.CanvasObject {
+		return newFileTreeNode(tree, branch)
 	}
-	tree.ChildUIDs = func(id widget.TreeNodeID) (c []string) {
-		listable, err := tree.toListable(id)
-		if err != nil {
-			fyne.LogError("Unable to get lister for "+id, err)
-			return
+	tree.IsBranch = func(id widget.TreeNodeID) bool {
+		if isPlaceholderNode(id) {
+			return false
 		}
-
-		uris, err := listable.List()
+		if tree.isWorkspaceRoot(id) {
+			return true
+		}
+		if _, err := tree.toListable(id); err == nil {
+			return true
+		}
+		// folders that cannot be read are shown with an error node in place of their children
+		u, err := tree.toURI(id)
 		if err != nil {
-			fyne.LogError("Unable to list "+listable.String(), err)
-			return
+			return false
 		}
-
-		for _, u := range tree.sort(tree.filter(uris)) {
-			// Convert to String
-			c = append(c, u.String())
+		metadata := fileMetadata(u)
+		return metadata != nil && metadata.Directory
+	}
+	tree.ChildUIDs = func(id widget.TreeNodeID) []string {
+		tree.cacheLock.Lock()
+		c, ok := tree.childCache[id]
+		tree.cacheLock.Unlock()
+		if ok {
+			return c
 		}
-		return
+
+		tree.loadChildren(id)
+		return []string{id + loadingNodeSuffix}
 	}
 	tree.UpdateNode = func(id widget.TreeNodeID, branch bool, node fyne.CanvasObject) {
+		n := node.(*fileTreeNode)
+		n.id = id
+		n.setHighlighted(tree.isMultiSelected(id))
+		n.setDropIndicator(tree.isDropFolder(id))
+		if isLoadingNode(id) {
+			n.icon.Hide()
+			n.customIcon.Hide()
+			n.label.SetText("Loading…")
+			n.setDecoration(nil)
+			n.setMatch(-1, -1)
+			n.setColumns(nil)
+			return
+		}
+		if isErrorNode(id) {
+			err := tree.BranchError(id)
+			n.icon.Hide()
+			n.customIcon.SetResource(errorIcon(err))
+			n.customIcon.Show()
+			n.label.SetText(errorMessage(err))
+			n.setDecoration(&FileDecoration{TextColor: theme.ErrorColor(), Suffix: "Tap to retry"})
+			n.setMatch(-1, -1)
+			n.setColumns(nil)
+			return
+		}
+		if tree.isWorkspaceRoot(id) {
+			n.customIcon.Hide()
+			n.icon.Show()
+			n.icon.(*widget.Icon).SetResource(theme.StorageIcon())
+			n.label.SetText(tree.workspaceName)
+			n.setDecoration(nil)
+			n.setMatch(-1, -1)
+			n.setColumns(nil)
+			return
+		}
+
 		uri, err := tree.toURI(id)
 		if err != nil {
 			fyne.LogError("Unable to parse URI", err)
 			return
 		}
 
-		c := node.(*fyne.Container)
-		if branch {
-			var r fyne.Resource
-			if tree.IsBranchOpen(id) {
-				// Set open folder icon
-				r = theme.FolderOpenIcon()
+		open := branch && tree.IsBranchOpen(id)
+		if r := tree.icon(uri, branch, open); r != nil {
+			n.icon.Hide()
+			n.customIcon.SetResource(r)
+			n.customIcon.Show()
+		} else {
+			n.customIcon.Hide()
+			n.icon.Show()
+			if branch {
+				var r fyne.Resource
+				if open {
+					// Set open folder icon
+					r = theme.FolderOpenIcon()
+				} else {
+					// Set folder icon
+					r = theme.FolderIcon()
+				}
+				n.icon.(*widget.Icon).SetResource(r)
 			} else {
-				// Set folder icon
-				r = theme.FolderIcon()
+				// Set file uri to update icon
+				n.icon.(*widget.FileIcon).SetURI(uri)
 			}
-			c.Objects[1].(*widget.Icon).SetResource(r)
-		} else {
-			// Set file uri to update icon
-			c.Objects[1].(*widget.FileIcon).SetURI(uri)
 		}
 
-		var l string
-		if tree.Root == id {
-			l = id
-		} else {
-			l = uri.Name()
-		}
-		c.Objects[0].(*widget.Label).SetText(l)
+		l := tree.label(id, uri)
+		n.label.SetText(l)
+		n.setDecoration(symlinkDecoration(uri, tree.decoration(uri)))
+		n.setMatch(tree.matchRange(l))
+		n.setColumns(uri)
 	}
 	tree.ExtendBaseWidget(tree)
 	return tree
 }
 
+// OpenAllBranches opens all branches in the tree.
+// Every directory below the root will be listed before returning, so this may be slow for large hierarchies.
+func (t *FileTree) OpenAllBranches() {
+	t.listAll(t.Root)
+	t.Tree.OpenAllBranches()
+
+	t.cacheLock.Lock()
+	ids := make([]widget.TreeNodeID, 0, len(t.childCache))
+	for id := range t.childCache {
+		ids = append(ids, id)
+	}
+	t.cacheLock.Unlock()
+	t.stateLock.Lock()
+	for _, id := range ids {
+		if t.IsBranchOpen(id) {
+			t.opened[id] = true
+		}
+	}
+	t.stateLock.Unlock()
+	t.stateChanged()
+}
+
+// CloseAllBranches closes all branches in the tree. Listings in progress are cancelled and, if a watcher is set,
+// the closed branches stop being watched, as they do when each branch is closed.
+func (t *FileTree) CloseAllBranches() {
+	t.Tree.CloseAllBranches()
+
+	t.cacheLock.Lock()
+	for id, cancel := range t.loading {
+		if id != t.Root { // the root is always shown
+			close(cancel)
+			delete(t.loading, id)
+		}
+	}
+	t.revealing = nil
+	var watched []widget.TreeNodeID
+	if t.watcher != nil {
+		for id := range t.watched {
+			watched = append(watched, id)
+		}
+	}
+	t.cacheLock.Unlock()
+	for _, id := range watched {
+		if id != t.Root && !t.isWorkspaceRoot(id) {
+			t.invalidate(id)
+		}
+	}
+
+	t.stateLock.Lock()
+	t.opened = make(map[widget.TreeNodeID]bool)
+	t.stateLock.Unlock()
+	t.stateChanged()
+	t.trimListings()
+}
+
+// branchClosed cancels the listing of a branch that is closed before it has loaded.
+// If a watcher is set the branch stops being watched, and is listed again when it next opens.
+// Closing a branch changes the state saved by PersistState.
+func (t *FileTree) branchClosed(id widget.TreeNodeID) {
+	t.cacheLock.Lock()
+	if cancel, ok := t.loading[id]; ok {
+		close(cancel)
+		delete(t.loading, id)
+	}
+	watching := t.watcher != nil
+	if r := t.revealing; r != nil && strings.HasPrefix(r.uri.String(), strings.TrimSuffix(id, "/")+"/") {
+		t.revealing = nil // don't open the branch again to reveal something inside it
+	}
+	t.cacheLock.Unlock()
+
+	if watching && !t.isWorkspaceRoot(id) {
+		t.invalidate(id)
+		t.unwatch(func(watched widget.TreeNodeID) bool {
+			return watched == id
+		})
+	}
+
+	t.stateLock.Lock()
+	delete(t.opened, id)
+	t.stateLock.Unlock()
+	t.stateChanged()
+	t.trimListings()
+
+	if f := t.OnBranchClosed; f != nil {
+		f(id)
+	}
+}
+
+// branchOpened records the branch for the state of the tree, which is saved if PersistState has been called.
+func (t *FileTree) branchOpened(id widget.TreeNodeID) {
+	t.stateLock.Lock()
+	t.opened[id] = true
+	t.stateLock.Unlock()
+	t.stateChanged()
+
+	if f := t.OnBranchOpened; f != nil {
+		f(id)
+	}
+}
+
+// branchID returns the ID of the node that represents a URI.
+// Roots are matched even if they differ from the URI by a trailing slash.
+func (t *FileTree) branchID(u fyne.URI) widget.TreeNodeID {
+	id := u.String()
+	if root, ok := t.rootID(id); ok {
+		return root
+	}
+	return id
+}
+
 func (t *FileTree) filter(uris []fyne.URI) []fyne.URI {
-	filter := t.Filter
-	if filter == nil {
-		return uris
+	return t.listOptions().filter(uris, t.IsBranch)
+}
+
+// listAll synchronously lists the branch and all branches below it, caching their children.
+func (t *FileTree) listAll(id widget.TreeNodeID) {
+	t.cacheLock.Lock()
+	children, ok := t.childCache[id]
+	t.cacheLock.Unlock()
+	if !ok {
+		children = t.listChildren(id, t.listOptions())
+		t.cacheLock.Lock()
+		t.childCache[id] = children
+		t.cacheLock.Unlock()
 	}
-	var filtered []fyne.URI
-	for _, u := range uris {
-		if filter.Matches(u) {
-			filtered = append(filtered, u)
+
+	for _, child := range children {
+		if t.IsBranch(child) {
+			t.listAll(child)
 		}
 	}
-	return filtered
+}
+
+// listChildren returns the filtered and sorted child IDs of a branch, listing it with storage.
+// It may be called in the background, so the settings of the tree are passed in.
+func (t *FileTree) listChildren(id widget.TreeNodeID, o fileListOptions) (c []widget.TreeNodeID) {
+	listable, err := t.toListable(id)
+	if err != nil {
+		return t.listFailed(id, err, o.onError)
+	}
+	if isSymlinkLoop(listable) {
+		return t.listFailed(id, errSymlinkLoop, o.onError)
+	}
+
+	uris, err := listable.List()
+	if err != nil {
+		return t.listFailed(id, err, o.onError)
+	}
+	t.watch(id, listable)
+
+	for _, u := range o.sort(o.filter(uris, t.IsBranch)) {
+		// Convert to String
+		c = append(c, u.String())
+	}
+	return
+}
+
+// listOptions returns the current settings that control how branches are listed.
+func (t *FileTree) listOptions() fileListOptions {
+	return fileListOptions{fileFilter: t.Filter, leavesOnly: t.FilterLeavesOnly, sorter: t.Sorter, onError: t.OnError}
+}
+
+// loadChildren starts listing an open branch in the background. Once the children are known they are cached
+// and the tree is refreshed. Listing is cancelled if the branch is closed, or the tree destroyed, before it
+// completes.
+func (t *FileTree) loadChildren(id widget.TreeNodeID) {
+	if !t.IsBranchOpen(id) {
+		return
+	}
+	o := t.listOptions()
+
+	t.cacheLock.Lock()
+	if _, ok := t.loading[id]; ok {
+		t.cacheLock.Unlock()
+		return
+	}
+	cancel := make(chan struct{})
+	t.loading[id] = cancel
+	t.background++
+	t.cacheLock.Unlock()
+
+	go func() {
+		defer t.backgroundDone()
+		select {
+		case t.workers <- struct{}{}:
+		case <-cancel:
+			return
+		}
+		defer func() { <-t.workers }()
+
+		var children []widget.TreeNodeID
+		select {
+		case <-cancel:
+			return
+		default:
+			children = t.listChildren(id, o)
+		}
+
+		t.cacheLock.Lock()
+		select {
+		case <-cancel:
+			t.cacheLock.Unlock()
+			return
+		default:
+		}
+		delete(t.loading, id)
+		t.childCache[id] = children
+		t.cacheLock.Unlock()
+		t.refreshLoaded()
+	}()
+}
+
+// refreshLoaded refreshes the tree to show changes found in the background, such as branches that have loaded.
+// Background work only refreshes the tree through here, so there is never more than one refresh waiting.
+func (t *FileTree) refreshLoaded() {
+	t.refreshLock.Lock()
+	defer t.refreshLock.Unlock()
+	if t.refreshPending {
+		return
+	}
+	t.refreshPending = true
+	t.cacheLock.Lock()
+	t.background++
+	t.cacheLock.Unlock()
+
+	time.AfterFunc(loadRefreshDelay, func() {
+		defer t.backgroundDone()
+		t.updateLock.Lock()
+		defer t.updateLock.Unlock()
+		t.refreshLock.Lock()
+		t.refreshPending = false
+		for t.rendering > 0 {
+			t.renderedCond().Wait()
+		}
+		t.refreshLock.Unlock()
+
+		t.continueReveal()
+		t.revealFirstMatch()
+		t.Refresh()
+		t.restoreScroll()
+	})
+}
+
+// renderedCond returns the condition signalled when the renderer is no longer in use. The refresh lock must be held.
+func (t *FileTree) renderedCond() *sync.Cond {
+	if t.rendered == nil {
+		t.rendered = sync.NewCond(&t.refreshLock)
+	}
+	return t.rendered
+}
+
+func (t *FileTree) startRendering() {
+	t.refreshLock.Lock()
+	t.rendering++
+	t.refreshLock.Unlock()
+}
+
+func (t *FileTree) doneRendering() {
+	t.refreshLock.Lock()
+	t.rendering--
+	if t.rendering == 0 {
+		t.renderedCond().Broadcast()
+	}
+	t.refreshLock.Unlock()
+}
+
+func (t *FileTree) backgroundDone() {
+	t.cacheLock.Lock()
+	t.background--
+	t.cacheLock.Unlock()
 }
 
 func (t *FileTree) sort(uris []fyne.URI) []fyne.URI {
-	if sorter := t.Sorter; sorter != nil {
-		sort.Slice(uris, func(i, j int) bool {
-			return sorter(uris[i], uris[j])
-		})
+	return t.listOptions().sort(uris)
+}
+
+// stopLoading cancels all of the branches that are being listed in the background.
+func (t *FileTree) stopLoading() {
+	t.cacheLock.Lock()
+	defer t.cacheLock.Unlock()
+	for id, cancel := range t.loading {
+		close(cancel)
+		delete(t.loading, id)
 	}
-	return uris
 }
 
 func (t *FileTree) toListable(id widget.TreeNodeID) (fyne.ListableURI, error) {
-	listable, ok := t.listableCache[id]
+	t.cacheLock.Lock()
+	cached, ok := t.listableCache.get(id)
+	t.cacheLock.Unlock()
 	if ok {
-		return listable, nil
+		return cached.(fyne.ListableURI), nil
 	}
 	uri, err := t.toURI(id)
 	if err != nil {
 		return nil, err
 	}
 
-	listable, err = storage.ListerForURI(uri)
+	listable, err := storage.ListerForURI(uri)
 	if err != nil {
 		return nil, err
 	}
-	t.listableCache[id] = listable
+	t.cacheLock.Lock()
+	t.listableCache.put(id, listable)
+	t.cacheLock.Unlock()
 	return listable, nil
 }
 
 func (t *FileTree) toURI(id widget.TreeNodeID) (fyne.URI, error) {
-	uri, ok := t.uriCache[id]
+	t.cacheLock.Lock()
+	cached, ok := t.uriCache.get(id)
+	t.cacheLock.Unlock()
 	if ok {
-		return uri, nil
+		return cached.(fyne.URI), nil
 	}
 
 	uri, err := storage.ParseURI(id)
 	if err != nil {
 		return nil, err
 	}
-	t.uriCache[id] = uri
+	t.cacheLock.Lock()
+	t.uriCache.put(id, uri)
+	t.cacheLock.Unlock()
 	return uri, nil
 }
+
+func isLoadingNode(id widget.TreeNodeID) bool {
+	return strings.HasSuffix(id, loadingNodeSuffix)
+}
+
+// fileListOptions are the settings of a FileTree that control how a branch is listed.
+// They are read before listing in the background, so that they can be changed while it runs.
+type fileListOptions struct {
+	fileFilter storage.FileFilter
+	leavesOnly bool
+	sorter     func(fyne.URI, fyne.URI) bool
+	onError    func(fyne.URI, error)
+}
+
+// filter returns the URIs that match the filter, using isBranch to find the folders when it only applies to files.
+func (o fileListOptions) filter(uris []fyne.URI, isBranch func(widget.TreeNodeID) bool) []fyne.URI {
+	if o.fileFilter == nil {
+		return uris
+	}
+	var filtered []fyne.URI
+	for _, u := range uris {
+		if o.leavesOnly && isBranch(u.String()) {
+			filtered = append(filtered, u)
+		} else if o.fileFilter.Matches(u) {
+			filtered = append(filtered, u)
+		}
+	}
+	return filtered
+}
+
+func (o fileListOptions) sort(uris []fyne.URI) []fyne.URI {
+	if sorter := o.sorter; sorter != nil {
+		sort.Slice(uris, func(i, j int) bool {
+			return sorter(uris[i], uris[j])
+		})
+	}
+	return uris
+}
+
+// fileTreeRenderer stops the background work of a FileTree when its renderer is destroyed,
+// which happens when the window showing it is closed. Refreshes for branches listed in the
+// background wait until the renderer is not being used by the caller's own refresh or layout.
+type fileTreeRenderer struct {
+	fyne.WidgetRenderer
+	tree *FileTree
+}
+
+func (r *fileTreeRenderer) Layout(size fyne.Size) {
+	r.tree.startRendering()
+	defer r.tree.doneRendering()
+	r.WidgetRenderer.Layout(size)
+}
+
+func (r *fileTreeRenderer) MinSize() fyne.Size {
+	r.tree.startRendering()
+	defer r.tree.doneRendering()
+	return r.WidgetRenderer.MinSize()
+}
+
+func (r *fileTreeRenderer) Refresh() {
+	r.tree.startRendering()
+	defer r.tree.doneRendering()
+	r.WidgetRenderer.Refresh()
+}
+
+func (r *fileTreeRenderer) Destroy() {
+	r.tree.stopLoading()
+	r.tree.stopWatching()
+	r.WidgetRenderer.Destroy()
+}
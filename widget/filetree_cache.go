@@ -0,0 +1,249 @@
+package widget
+
+import (
+	"container/list"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// DefaultFileTreeCacheSize is the number of URIs, and of listable URIs, that a FileTree keeps before discarding
+// the least recently used. It is also the number of listed branches kept before those that are not shown are discarded.
+const DefaultFileTreeCacheSize = 4096
+
+// FileTreeCacheStats describes how well the caches of a FileTree are working, to help choose their size.
+type FileTreeCacheStats struct {
+	// Hits is the number of lookups that were answered from the caches.
+	Hits uint64
+	// Misses is the number of lookups that had to parse or resolve a URI.
+	Misses uint64
+	// Evictions is the number of entries, including listed branches, discarded to stay within the size.
+	Evictions uint64
+	// Size is the number of URIs, listable URIs and listed branches currently held, and Capacity is the most
+	// that they will hold together, or 0 if there is no limit. Branches that are shown are kept even if
+	// this makes Size larger than Capacity.
+	Size, Capacity int
+}
+
+// HitRate returns the fraction of lookups that were answered from the caches, or 0 if there have been none.
+func (s FileTreeCacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// CacheStats returns the hit rate of the caches that map node IDs to URIs and listable URIs,
+// and the size of those caches together with the branches that have been listed.
+func (t *FileTree) CacheStats() FileTreeCacheStats {
+	t.cacheLock.Lock()
+	defer t.cacheLock.Unlock()
+	stats := FileTreeCacheStats{
+		Hits:      t.uriCache.hits + t.listableCache.hits,
+		Misses:    t.uriCache.misses + t.listableCache.misses,
+		Evictions: t.uriCache.evictions + t.listableCache.evictions + t.listingEvictions,
+		Size:      t.uriCache.len() + t.listableCache.len() + len(t.childCache),
+	}
+	if t.uriCache.capacity > 0 && t.listableCache.capacity > 0 && t.listingLimit > 0 {
+		stats.Capacity = t.uriCache.capacity + t.listableCache.capacity + t.listingLimit
+	}
+	return stats
+}
+
+// Invalidate discards everything cached for a URI and the nodes below it, so that it is resolved and,
+// if it is an open branch, listed again. It is useful when the file system changes without a FileWatcher set.
+func (t *FileTree) Invalidate(u fyne.URI) {
+	id := t.branchID(u)
+	t.cacheLock.Lock()
+	t.uriCache.remove(id)
+	t.listableCache.remove(id)
+	t.cacheLock.Unlock()
+	t.invalidate(id)
+	t.Refresh()
+}
+
+// Reload discards all cached URIs, listings and metadata, so that the open branches are listed again.
+// Listings that are still loading are cancelled, as they may have read the folder before it changed.
+func (t *FileTree) Reload() {
+	t.cacheLock.Lock()
+	for id, cancel := range t.loading {
+		close(cancel)
+		delete(t.loading, id)
+	}
+	t.uriCache.clear()
+	t.listableCache.clear()
+	for id := range t.childCache {
+		if id != t.Root || t.rootNames == nil { // the roots of a workspace are not listed
+			delete(t.childCache, id)
+		}
+	}
+	t.errors = make(map[widget.TreeNodeID]error)
+	t.cacheLock.Unlock()
+	t.unwatch(func(widget.TreeNodeID) bool {
+		return true
+	})
+	metadataLock.Lock()
+	metadataCache.clear()
+	metadataLock.Unlock()
+	t.Refresh()
+}
+
+// SetCacheSize changes how many URIs, and how many listable URIs, the tree keeps before discarding the least
+// recently used, and how many branches keep their children before those that are not shown are listed again
+// when they next open. A size of 0 or less removes the limit.
+func (t *FileTree) SetCacheSize(size int) {
+	t.cacheLock.Lock()
+	t.uriCache.resize(size)
+	t.listableCache.resize(size)
+	if size < 0 {
+		size = 0
+	}
+	t.listingLimit = size
+	t.cacheLock.Unlock()
+	t.trimListings()
+}
+
+// trimListings discards the children, errors and watches of branches that are not shown, such as those inside
+// a closed branch, while more branches are listed than the cache size allows.
+func (t *FileTree) trimListings() {
+	t.cacheLock.Lock()
+	limit := t.listingLimit
+	count := len(t.childCache)
+	t.cacheLock.Unlock()
+	if limit <= 0 || count <= limit {
+		return
+	}
+
+	shown := t.shownBranches()
+	t.cacheLock.Lock()
+	var hidden []widget.TreeNodeID
+	for id := range t.childCache {
+		if !shown[id] {
+			hidden = append(hidden, id)
+		}
+	}
+	t.cacheLock.Unlock()
+
+	sort.Strings(hidden) // parents first, their children go with them
+	for _, id := range hidden {
+		t.cacheLock.Lock()
+		_, listed := t.childCache[id]
+		count = len(t.childCache)
+		t.cacheLock.Unlock()
+		if count <= limit {
+			return
+		}
+		if !listed {
+			continue
+		}
+
+		t.invalidate(id)
+		t.cacheLock.Lock()
+		t.listingEvictions += uint64(count - len(t.childCache))
+		t.cacheLock.Unlock()
+	}
+}
+
+// shownBranches returns the branches that the tree shows the children of, the root and the open
+// branches whose parents are shown.
+func (t *FileTree) shownBranches() map[widget.TreeNodeID]bool {
+	shown := make(map[widget.TreeNodeID]bool)
+	var walk func(id widget.TreeNodeID)
+	walk = func(id widget.TreeNodeID) {
+		shown[id] = true
+		t.cacheLock.Lock()
+		children := t.childCache[id]
+		t.cacheLock.Unlock()
+		for _, child := range children {
+			if !shown[child] && t.IsBranchOpen(child) {
+				walk(child)
+			}
+		}
+	}
+	walk(t.Root)
+	return shown
+}
+
+// lruCache maps node IDs, or other keys, to values, discarding the least recently used entry when it is full.
+// It is not safe for concurrent use, the lock of the FileTree, or of the metadata, protects it.
+type lruCache struct {
+	capacity int
+	entries  map[widget.TreeNodeID]*list.Element
+	order    *list.List // most recently used at the front
+
+	hits, misses, evictions uint64
+}
+
+type lruEntry struct {
+	id    widget.TreeNodeID
+	value interface{}
+}
+
+func newLRUCache(capacity int) *lruCache {
+	return &lruCache{capacity: capacity, entries: make(map[widget.TreeNodeID]*list.Element), order: list.New()}
+}
+
+func (c *lruCache) clear() {
+	c.entries = make(map[widget.TreeNodeID]*list.Element)
+	c.order.Init()
+}
+
+func (c *lruCache) get(id widget.TreeNodeID) (interface{}, bool) {
+	e, ok := c.entries[id]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.order.MoveToFront(e)
+	return e.Value.(*lruEntry).value, true
+}
+
+func (c *lruCache) len() int {
+	return c.order.Len()
+}
+
+func (c *lruCache) put(id widget.TreeNodeID, value interface{}) {
+	if e, ok := c.entries[id]; ok {
+		e.Value.(*lruEntry).value = value
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[id] = c.order.PushFront(&lruEntry{id: id, value: value})
+	c.trim()
+}
+
+func (c *lruCache) remove(id widget.TreeNodeID) {
+	if e, ok := c.entries[id]; ok {
+		c.order.Remove(e)
+		delete(c.entries, id)
+	}
+}
+
+// removePrefix removes the entries with IDs that start with the prefix.
+func (c *lruCache) removePrefix(prefix string) {
+	for id, e := range c.entries {
+		if strings.HasPrefix(id, prefix) {
+			c.order.Remove(e)
+			delete(c.entries, id)
+		}
+	}
+}
+
+func (c *lruCache) resize(capacity int) {
+	c.capacity = capacity
+	c.trim()
+}
+
+// trim discards the least recently used entries until the cache is within its capacity.
+func (c *lruCache) trim() {
+	for c.capacity > 0 && c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).id)
+		c.evictions++
+	}
+}
@@ -0,0 +1,169 @@
+package widget
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestFileTree_CacheSize(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	tree.SetCacheSize(2)
+	tree.OpenAllBranches()
+
+	stats := tree.CacheStats()
+	assert.Equal(t, 6, stats.Capacity) // URIs, listable URIs and listed branches
+	assert.LessOrEqual(t, stats.Size, 4+len(tree.childCache))
+	assert.NotZero(t, stats.Evictions)
+	assert.NotZero(t, stats.Misses)
+
+	branch, _ := storage.Child(root, "B")
+	tree.IsBranch(branch.String())
+	hits := tree.CacheStats().Hits
+	tree.IsBranch(branch.String()) // recently used, so still cached
+	assert.Equal(t, hits+1, tree.CacheStats().Hits)
+	assert.Greater(t, tree.CacheStats().HitRate(), 0.0)
+
+	tree.SetCacheSize(0)
+	tree.OpenAllBranches()
+	assert.Equal(t, 0, tree.CacheStats().Capacity)
+}
+
+func TestFileTree_CacheSize_Listings(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	branchA, _ := storage.Child(root, "A")
+	branchB, _ := storage.Child(root, "B")
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	tree.SetCacheSize(2)
+	tree.listAll(root.String())
+	tree.OpenBranch(branchA.String())
+	tree.OpenBranch(branchB.String())
+	waitForFileTree(t, tree)
+	assert.Len(t, tree.childCache, 3) // shown branches are kept
+
+	tree.CloseBranch(branchA.String())
+	_, listed := tree.childCache[branchA.String()]
+	assert.False(t, listed)
+	assert.Equal(t, uint64(1), tree.listingEvictions)
+
+	tree.CloseBranch(branchB.String()) // within the size
+	_, listed = tree.childCache[branchB.String()]
+	assert.True(t, listed)
+
+	tree.SetCacheSize(1)
+	_, listed = tree.childCache[branchB.String()]
+	assert.False(t, listed)
+	_, listed = tree.childCache[root.String()]
+	assert.True(t, listed)
+}
+
+func TestFileTree_InvalidateReload(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	branch, _ := storage.Child(root, "B")
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	tree.listAll(root.String())
+	assert.Len(t, tree.ChildUIDs(branch.String()), 2)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(tempDir, "B", "E.txt"), []byte("e"), 0644))
+	assert.Len(t, tree.ChildUIDs(branch.String()), 2) // cached
+
+	tree.Invalidate(branch)
+	tree.listAll(root.String())
+	assert.Len(t, tree.ChildUIDs(branch.String()), 3)
+
+	assert.NoError(t, os.Mkdir(filepath.Join(tempDir, "F"), 0755))
+	assert.Len(t, tree.ChildUIDs(root.String()), 2)
+	tree.Reload()
+	tree.listAll(root.String())
+	assert.Len(t, tree.ChildUIDs(root.String()), 3)
+}
+
+func TestFileTree_Reload_Loading(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	leaf, _ := storage.Child(root, "A")
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	size := fileMetadata(leaf).Size
+
+	// occupy all of the workers so the listing has to wait
+	for i := 0; i < maxListWorkers; i++ {
+		tree.workers <- struct{}{}
+	}
+	tree.ChildUIDs(root.String())
+	tree.cacheLock.Lock()
+	cancel := tree.loading[root.String()]
+	tree.cacheLock.Unlock()
+	assert.NotNil(t, cancel)
+
+	assert.NoError(t, os.Mkdir(filepath.Join(tempDir, "F"), 0755))
+	assert.NoError(t, os.RemoveAll(leaf.Path()))
+	assert.NoError(t, ioutil.WriteFile(leaf.Path(), []byte("a file now"), 0644))
+	tree.Reload()
+	select {
+	case <-cancel:
+	default:
+		t.Error("the listing was not cancelled")
+	}
+	assert.NotEqual(t, size, fileMetadata(leaf).Size)
+	for i := 0; i < maxListWorkers; i++ {
+		<-tree.workers
+	}
+
+	tree.listAll(root.String())
+	assert.Len(t, tree.ChildUIDs(root.String()), 3)
+	assert.False(t, tree.IsBranch(leaf.String()))
+}
+
+func TestLRUCache(t *testing.T) {
+	c := newLRUCache(2)
+	c.put("a", 1)
+	c.put("b", 2)
+	c.get("a")
+	c.put("c", 3) // evicts b, the least recently used
+
+	_, ok := c.get("b")
+	assert.False(t, ok)
+	v, ok := c.get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	assert.Equal(t, uint64(1), c.evictions)
+
+	c.resize(3)
+	c.put("a/x", 4)
+	c.removePrefix("a/")
+	assert.Equal(t, 2, c.len())
+	c.resize(1)
+	assert.Equal(t, 1, c.len())
+	_, ok = c.get("a")
+	assert.True(t, ok)
+}
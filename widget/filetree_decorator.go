@@ -0,0 +1,35 @@
+package widget
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+)
+
+// FileDecoration is extra information shown with the name of a node in a FileTree.
+type FileDecoration struct {
+	// Badge is a short marker shown at the end of the node, such as "M" for a modified file.
+	Badge string
+	// BadgeColor is the colour of the badge, if it is nil the theme text colour is used.
+	BadgeColor color.Color
+	// TextColor replaces the colour of the name, if it is nil the theme text colour is used.
+	TextColor color.Color
+	// Suffix is a description shown after the name in a dimmed colour.
+	Suffix string
+}
+
+// FileDecorator provides the decorations for nodes in a FileTree.
+// Decorate is called each time a node is shown so it should return quickly,
+// gathering the information needed in advance.
+type FileDecorator interface {
+	// Decorate returns the decoration for a URI, or nil if it should be shown plainly.
+	Decorate(fyne.URI) *FileDecoration
+}
+
+// decoration returns the decoration for a URI from the tree's decorator, if one is set.
+func (t *FileTree) decoration(u fyne.URI) *FileDecoration {
+	if d := t.Decorator; d != nil {
+		return d.Decorate(u)
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package widget
+
+import (
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// hoverExpandDelay is how long URIs must be dragged over a closed folder before it is opened.
+const hoverExpandDelay = 700 * time.Millisecond
+
+var _ FileDropTarget = (*FileTree)(nil)
+
+// FileDropTarget is implemented by objects that accept URIs dragged out of a FileTree.
+// Targets are registered with FileTree.AddDropTarget. A FileTree is itself a target, so URIs can be
+// dropped on to its folders from other sources by calling these methods.
+type FileDropTarget interface {
+	fyne.CanvasObject
+	// FileDragMoved is called as URIs are dragged over the target, with a position relative to it.
+	// It returns true if the URIs can be dropped at that position.
+	FileDragMoved(uris []fyne.URI, pos fyne.Position) bool
+	// FileDragExited is called when the URIs are dragged away from the target, or the drag ends.
+	FileDragExited()
+	// FileDropped is called when URIs are released over the target at a position where they can be dropped.
+	FileDropped(uris []fyne.URI, pos fyne.Position)
+}
+
+type fileTreeDrag struct {
+	uris     []fyne.URI
+	target   FileDropTarget
+	pos      fyne.Position
+	accepted bool
+}
+
+// AddDropTarget registers an object that URIs can be dragged on to from this tree.
+// Targets added later are checked first, so a target inside another should be added after it.
+func (t *FileTree) AddDropTarget(target FileDropTarget) {
+	t.dragLock.Lock()
+	defer t.dragLock.Unlock()
+	t.dropTargets = append(t.dropTargets, target)
+}
+
+// RemoveDropTarget stops URIs being dragged on to a target added by AddDropTarget.
+func (t *FileTree) RemoveDropTarget(target FileDropTarget) {
+	t.dragLock.Lock()
+	defer t.dragLock.Unlock()
+	for i, other := range t.dropTargets {
+		if other == target {
+			t.dropTargets = append(t.dropTargets[:i:i], t.dropTargets[i+1:]...)
+			return
+		}
+	}
+}
+
+// FileDragExited removes the drop indicator when URIs are dragged away from the tree.
+//
+// Implements: FileDropTarget
+func (t *FileTree) FileDragExited() {
+	t.setDropFolder("", "")
+}
+
+// FileDragMoved shows where URIs dragged over the tree would be dropped, and opens a closed folder if they are held
+// over it. URIs can be dropped on to a folder, or a file to drop them in its folder, if OnDropped is set or
+// operations are enabled. A folder cannot be dropped into itself.
+//
+// Implements: FileDropTarget
+func (t *FileTree) FileDragMoved(uris []fyne.URI, pos fyne.Position) bool {
+	n, folder := t.dropFolderAt(pos)
+	if folder == nil || !t.canDrop(uris, folder) {
+		t.setDropFolder("", "")
+		return false
+	}
+
+	t.setDropFolder(t.branchID(folder), n.id)
+	return true
+}
+
+// FileDropped passes URIs dropped on to the tree to OnDropped with the folder they were dropped in,
+// or moves them there if OnDropped is not set and operations are enabled.
+//
+// Implements: FileDropTarget
+func (t *FileTree) FileDropped(uris []fyne.URI, pos fyne.Position) {
+	_, folder := t.dropFolderAt(pos)
+	t.setDropFolder("", "")
+	if folder == nil || !t.canDrop(uris, folder) {
+		return
+	}
+
+	if f := t.OnDropped; f != nil {
+		f(uris, folder)
+		return
+	}
+	for _, u := range uris {
+		if parent, err := storage.Parent(u); err == nil && t.branchID(parent) == t.branchID(folder) {
+			continue // already there
+		}
+		t.operations.Move(u, folder)
+	}
+}
+
+// canDrop returns true if the URIs can be dropped in the folder.
+func (t *FileTree) canDrop(uris []fyne.URI, folder fyne.URI) bool {
+	if len(uris) == 0 || (t.OnDropped == nil && t.operations == nil) {
+		return false
+	}
+
+	dest := strings.TrimSuffix(folder.String(), "/")
+	for _, u := range uris {
+		id := strings.TrimSuffix(u.String(), "/")
+		if dest == id || strings.HasPrefix(dest, id+"/") {
+			return false
+		}
+	}
+	return true
+}
+
+// dragEnded drops the dragged URIs on the target below the pointer, if it accepted them.
+func (t *FileTree) dragEnded() {
+	t.dragLock.Lock()
+	drag := t.drag
+	t.drag = nil
+	t.dragLock.Unlock()
+	if drag == nil || drag.target == nil {
+		return
+	}
+
+	if drag.accepted {
+		drag.target.FileDropped(drag.uris, drag.pos)
+	}
+	drag.target.FileDragExited()
+}
+
+// scrollDragged scrolls the tree by a drag that started on one of its nodes, as the scroller would if the
+// node did not handle the drag itself.
+func (t *FileTree) scrollDragged(ev *fyne.DragEvent) {
+	t.stateLock.Lock()
+	scroller := t.scroller
+	t.stateLock.Unlock()
+	if scroller != nil {
+		scroller.Dragged(ev)
+	}
+}
+
+// dragMoved tells the drop target below the pointer that the node, or the selection it is part of,
+// has been dragged to an absolute position.
+func (t *FileTree) dragMoved(id widget.TreeNodeID, pos fyne.Position) {
+	t.dragLock.Lock()
+	drag := t.drag
+	if drag == nil {
+		drag = &fileTreeDrag{}
+		for _, selected := range t.selectedOrNode(id) {
+			if u, err := t.toURI(selected); err == nil {
+				drag.uris = append(drag.uris, u)
+			}
+		}
+		t.drag = drag
+	}
+	targets := append([]FileDropTarget{}, t.dropTargets...)
+	t.dragLock.Unlock()
+
+	var target FileDropTarget
+	var targetPos fyne.Position
+	for i := len(targets) - 1; i >= -1 && target == nil; i-- {
+		candidate := FileDropTarget(t)
+		if i >= 0 {
+			candidate = targets[i]
+		}
+		if p, ok := positionInObject(candidate, pos); ok {
+			target, targetPos = candidate, p
+		}
+	}
+
+	if old := drag.target; old != nil && old != target {
+		old.FileDragExited()
+	}
+	drag.target, drag.pos, drag.accepted = target, targetPos, false
+	if target != nil {
+		drag.accepted = target.FileDragMoved(drag.uris, targetPos)
+	}
+}
+
+// dropFolderAt returns the node at a position relative to the tree, and the folder that URIs dropped there go into.
+func (t *FileTree) dropFolderAt(pos fyne.Position) (*fileTreeNode, fyne.URI) {
+	abs := fyne.CurrentApp().Driver().AbsolutePositionForObject(t)
+	n := t.nodeAtPosition(abs.Add(pos))
+	if n == nil || isPlaceholderNode(n.id) || t.isWorkspaceRoot(n.id) {
+		return nil, nil
+	}
+
+	u, err := t.toURI(n.id)
+	if err != nil {
+		return nil, nil
+	}
+	if t.IsBranch(n.id) {
+		return n, u
+	}
+	if t.isRoot(n.id) {
+		return nil, nil
+	}
+	folder, err := storage.Parent(u)
+	if err != nil {
+		return nil, nil
+	}
+	return n, folder
+}
+
+// setDropFolder shows the drop indicator on a folder, and opens it after a delay if the pointer is held over it.
+// The hovered node is the one below the pointer, which may be a file in the folder.
+func (t *FileTree) setDropFolder(folder, hovered widget.TreeNodeID) {
+	t.dragLock.Lock()
+	changed := folder != t.dropFolder
+	t.dropFolder = folder
+	if hovered != t.hovered {
+		t.hovered = hovered
+		if t.hoverTimer != nil {
+			if t.hoverTimer.Stop() {
+				t.backgroundDone()
+			}
+			t.hoverTimer = nil
+		}
+		if hovered != "" && hovered == folder && !t.IsBranchOpen(hovered) {
+			t.cacheLock.Lock()
+			t.background++
+			t.cacheLock.Unlock()
+			t.hoverTimer = time.AfterFunc(hoverExpandDelay, func() {
+				defer t.backgroundDone()
+				t.dragLock.Lock()
+				still := t.hovered == hovered
+				t.dragLock.Unlock()
+				if still {
+					t.OpenBranch(hovered)
+				}
+			})
+		}
+	}
+	t.dragLock.Unlock()
+
+	if changed {
+		t.Refresh()
+	}
+}
+
+func (t *FileTree) isDropFolder(id widget.TreeNodeID) bool {
+	t.dragLock.Lock()
+	defer t.dragLock.Unlock()
+	return id != "" && id == t.dropFolder
+}
+
+// positionInObject returns the position relative to a visible object, if the absolute position is inside it.
+func positionInObject(o fyne.CanvasObject, pos fyne.Position) (fyne.Position, bool) {
+	if !o.Visible() || fyne.CurrentApp().Driver().CanvasForObject(o) == nil {
+		return fyne.Position{}, false
+	}
+
+	abs := fyne.CurrentApp().Driver().AbsolutePositionForObject(o)
+	rel := pos.Subtract(abs)
+	size := o.Size()
+	if rel.X < 0 || rel.Y < 0 || rel.X >= size.Width || rel.Y >= size.Height {
+		return fyne.Position{}, false
+	}
+	return rel, true
+}
@@ -0,0 +1,117 @@
+package widget
+
+import (
+	"image/color"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+)
+
+type testDropTarget struct {
+	*canvas.Rectangle
+	dropped []fyne.URI
+	exited  int
+}
+
+func (t *testDropTarget) FileDragMoved([]fyne.URI, fyne.Position) bool {
+	return true
+}
+
+func (t *testDropTarget) FileDragExited() {
+	t.exited++
+}
+
+func (t *testDropTarget) FileDropped(uris []fyne.URI, _ fyne.Position) {
+	t.dropped = uris
+}
+
+func TestFileTree_DragOut(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	tree.OpenAllBranches()
+	target := &testDropTarget{Rectangle: canvas.NewRectangle(color.Black)}
+	tree.AddDropTarget(target)
+	window := test.NewWindow(container.NewGridWithColumns(2, tree, target))
+	defer window.Close()
+	window.Resize(fyne.NewSize(600, 300))
+
+	branch, _ := storage.Child(root, "B")
+	leafC, _ := storage.Child(branch, "C.txt")
+	leafD, _ := storage.Child(branch, "D.txt")
+	tree.SetSelectedURIs([]fyne.URI{leafC, leafD})
+
+	d := fyne.CurrentApp().Driver()
+	from := d.AbsolutePositionForObject(findFileTreeNode(tree, leafC.String())).Add(fyne.NewPos(5, 5))
+	to := d.AbsolutePositionForObject(target).Add(fyne.NewPos(10, 10))
+	test.Drag(window.Canvas(), from, to.X-from.X, to.Y-from.Y)
+	assert.Equal(t, []fyne.URI{leafC, leafD}, target.dropped)
+	assert.Equal(t, 1, target.exited)
+
+	tree.RemoveDropTarget(target)
+	target.dropped = nil
+	test.Drag(window.Canvas(), from, to.X-from.X, to.Y-from.Y)
+	assert.Nil(t, target.dropped)
+}
+
+func TestFileTree_DropOn(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	tree.listAll(root.String())
+	window := test.NewWindow(tree)
+	defer window.Close()
+	waitForFileTree(t, tree)
+	window.Resize(fyne.NewSize(300, 300))
+	waitForFileTree(t, tree)
+
+	branchA, _ := storage.Child(root, "A")
+	branchB, _ := storage.Child(root, "B")
+	external := storage.NewFileURI("/elsewhere/file.txt")
+	position := func(u fyne.URI) fyne.Position {
+		d := fyne.CurrentApp().Driver()
+		return d.AbsolutePositionForObject(findFileTreeNode(tree, u.String())).
+			Subtract(d.AbsolutePositionForObject(tree)).Add(fyne.NewPos(5, 5))
+	}
+
+	assert.False(t, tree.FileDragMoved([]fyne.URI{external}, position(branchA))) // nothing handles drops
+
+	var dropped []fyne.URI
+	var folder fyne.URI
+	tree.OnDropped = func(uris []fyne.URI, f fyne.URI) {
+		dropped, folder = uris, f
+	}
+	assert.True(t, tree.FileDragMoved([]fyne.URI{external}, position(branchA)))
+	assert.True(t, findFileTreeNode(tree, branchA.String()).dropIndicator.Visible())
+	assert.False(t, tree.FileDragMoved([]fyne.URI{branchA}, position(branchA))) // into itself
+	assert.False(t, findFileTreeNode(tree, branchA.String()).dropIndicator.Visible())
+
+	assert.True(t, tree.FileDragMoved([]fyne.URI{external}, position(branchB)))
+	assert.Eventually(t, func() bool {
+		return tree.IsBranchOpen(branchB.String())
+	}, 2*time.Second, 10*time.Millisecond)
+	waitForFileTree(t, tree)
+
+	tree.FileDropped([]fyne.URI{external}, position(branchB))
+	assert.Equal(t, []fyne.URI{external}, dropped)
+	assert.Equal(t, branchB.String(), folder.String())
+	assert.False(t, findFileTreeNode(tree, branchB.String()).dropIndicator.Visible())
+}
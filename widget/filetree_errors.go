@@ -0,0 +1,136 @@
+package widget
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// errorNodeSuffix is added to the ID of a branch to create the node shown when it cannot be listed.
+const errorNodeSuffix = "\x00error"
+
+// errSymlinkLoop is reported for a symbolic link to a folder that contains the link, which would never end.
+var errSymlinkLoop = errors.New("symbolic link loop")
+
+var lockIcon = theme.NewThemedResource(&fyne.StaticResource{
+	StaticName: "lock.svg",
+	StaticContent: []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24">` +
+		`<path d="M18 8h-1V6c0-2.76-2.24-5-5-5S7 3.24 7 6v2H6c-1.1 0-2 .9-2 2v10c0 1.1.9 2 2 2h12c1.1 0 2-.9 2-2V10c0-1.1-.9-2-2-2z` +
+		`m-6 9c-1.1 0-2-.9-2-2s.9-2 2-2 2 .9 2 2-.9 2-2 2zm3.1-9H8.9V6c0-1.71 1.39-3.1 3.1-3.1 1.71 0 3.1 1.39 3.1 3.1v2z"/></svg>`),
+})
+
+// BranchError returns the error that stopped a branch from being listed, or nil if it was listed
+// or has not been listed yet.
+func (t *FileTree) BranchError(uid widget.TreeNodeID) error {
+	t.cacheLock.Lock()
+	defer t.cacheLock.Unlock()
+	return t.errors[strings.TrimSuffix(uid, errorNodeSuffix)]
+}
+
+// Retry lists a branch again after it failed, replacing its error node with its children.
+// The ID of the error node can also be passed. Tapping an error node retries its branch.
+// An open branch is listed in the background and the tree refreshed once it completes.
+func (t *FileTree) Retry(uid widget.TreeNodeID) {
+	id := strings.TrimSuffix(uid, errorNodeSuffix)
+	t.invalidate(id)
+	t.loadChildren(id)
+}
+
+// listFailed records the error for a branch, reporting it to onError, and returns the error node shown in its place.
+func (t *FileTree) listFailed(id widget.TreeNodeID, err error, onError func(fyne.URI, error)) []widget.TreeNodeID {
+	t.cacheLock.Lock()
+	t.errors[id] = err
+	t.cacheLock.Unlock()
+
+	if f := onError; f != nil {
+		if u, uriErr := t.toURI(id); uriErr == nil {
+			f(u, err)
+			return []widget.TreeNodeID{id + errorNodeSuffix}
+		}
+	}
+	fyne.LogError("Unable to list "+id, err)
+	return []widget.TreeNodeID{id + errorNodeSuffix}
+}
+
+// symlinkDecoration adds a description of the link target to the decoration of a URI that is a symbolic link.
+func symlinkDecoration(u fyne.URI, d *FileDecoration) *FileDecoration {
+	metadata := fileMetadata(u)
+	if metadata == nil || !metadata.Symlink {
+		return d
+	}
+
+	linked := FileDecoration{}
+	if d != nil {
+		linked = *d
+	}
+	suffix := "→ " + metadata.LinkTarget
+	if linked.Suffix != "" {
+		suffix = linked.Suffix + " " + suffix
+	}
+	linked.Suffix = suffix
+	return &linked
+}
+
+func errorIcon(err error) fyne.Resource {
+	switch {
+	case errors.Is(err, os.ErrPermission):
+		return lockIcon
+	case errors.Is(err, errSymlinkLoop):
+		return theme.WarningIcon()
+	}
+	return theme.ErrorIcon()
+}
+
+func errorMessage(err error) string {
+	switch {
+	case err == nil:
+		return "Unable to list folder"
+	case errors.Is(err, os.ErrPermission):
+		return "Permission denied"
+	case errors.Is(err, errSymlinkLoop):
+		return "Symbolic link loop"
+	}
+	return strings.SplitN(err.Error(), "\n", 2)[0]
+}
+
+func isErrorNode(id widget.TreeNodeID) bool {
+	return strings.HasSuffix(id, errorNodeSuffix)
+}
+
+// isPlaceholderNode returns true for the loading and error nodes that stand in for the children of a branch.
+func isPlaceholderNode(id widget.TreeNodeID) bool {
+	return isLoadingNode(id) || isErrorNode(id)
+}
+
+// isSymlinkLoop returns true if the URI is a local symbolic link to a folder that contains the link, or to a folder
+// that the branch has already passed through, such as links between folders that lead back to each other.
+// Listing such a folder would show the link again inside it, without end.
+func isSymlinkLoop(u fyne.URI) bool {
+	if u.Scheme() != "file" {
+		return false
+	}
+	path := filepath.Clean(u.Path())
+	info, err := os.Lstat(path)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		return false
+	}
+
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return false
+	}
+	inside := strings.TrimSuffix(target, string(filepath.Separator)) + string(filepath.Separator)
+	for parent := filepath.Dir(path); ; parent = filepath.Dir(parent) {
+		if real, err := filepath.EvalSymlinks(parent); err == nil && (real == target || strings.HasPrefix(real, inside)) {
+			return true
+		}
+		if filepath.Dir(parent) == parent {
+			return false
+		}
+	}
+}
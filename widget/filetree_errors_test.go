@@ -0,0 +1,125 @@
+package widget
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestFileTree_SymlinkLoop(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+	if err := os.Symlink(tempDir, filepath.Join(tempDir, "B", "loop")); err != nil {
+		t.Skip("Symbolic links are not supported", err)
+	}
+	assert.NoError(t, os.Symlink("C.txt", filepath.Join(tempDir, "B", "link.txt")))
+
+	root := storage.NewFileURI(tempDir)
+	branch, _ := storage.Child(root, "B")
+	loop, _ := storage.Child(branch, "loop")
+	link, _ := storage.Child(branch, "link.txt")
+
+	var failed []fyne.URI
+	var lock sync.Mutex
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	tree.OnError = func(u fyne.URI, err error) {
+		assert.True(t, errors.Is(err, errSymlinkLoop))
+		lock.Lock()
+		failed = append(failed, u)
+		lock.Unlock()
+	}
+	tree.OpenAllBranches() // should not recurse forever
+	window := test.NewWindow(tree)
+	defer window.Close()
+	waitForFileTree(t, tree)
+	window.Resize(fyne.NewSize(300, 600))
+	waitForFileTree(t, tree)
+
+	assert.Equal(t, []fyne.URI{loop}, failed)
+	assert.Equal(t, []string{loop.String() + errorNodeSuffix}, tree.ChildUIDs(loop.String()))
+	assert.Equal(t, errSymlinkLoop, tree.BranchError(loop.String()))
+	node := findFileTreeNode(tree, loop.String()+errorNodeSuffix)
+	assert.Equal(t, "Symbolic link loop", node.label.Text)
+	assert.Equal(t, "→ "+tempDir, findFileTreeNode(tree, loop.String()).suffix.Text)
+	assert.Equal(t, "→ C.txt", findFileTreeNode(tree, link.String()).suffix.Text)
+
+	tree.SelectAll()
+	assert.NotContains(t, tree.SelectedURIs(), nil)
+	for _, id := range tree.selection {
+		assert.False(t, isErrorNode(id))
+	}
+
+	test.Tap(node) // lists the branch again in the background
+	assert.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(failed) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, errSymlinkLoop, tree.BranchError(loop.String()))
+}
+
+func TestFileTree_SymlinkLoop_Folders(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+	assert.NoError(t, os.Mkdir(filepath.Join(tempDir, "E"), os.ModePerm))
+	// each folder links to the next, and the last back to the first
+	if err := os.Symlink(filepath.Join(tempDir, "B"), filepath.Join(tempDir, "A", "toB")); err != nil {
+		t.Skip("Symbolic links are not supported", err)
+	}
+	assert.NoError(t, os.Symlink(filepath.Join(tempDir, "E"), filepath.Join(tempDir, "B", "toE")))
+	assert.NoError(t, os.Symlink(filepath.Join(tempDir, "A"), filepath.Join(tempDir, "E", "toA")))
+
+	var failed []string
+	var lock sync.Mutex
+	tree := NewFileTree(storage.NewFileURI(tempDir))
+	defer waitForFileTree(t, tree)
+	tree.OnError = func(u fyne.URI, err error) {
+		assert.True(t, errors.Is(err, errSymlinkLoop))
+		lock.Lock()
+		failed = append(failed, u.Path())
+		lock.Unlock()
+	}
+	tree.OpenAllBranches() // should not recurse forever
+
+	assert.ElementsMatch(t, []string{
+		filepath.Join(tempDir, "A", "toB", "toE", "toA"),
+		filepath.Join(tempDir, "B", "toE", "toA", "toB"),
+		filepath.Join(tempDir, "E", "toA", "toB", "toE"),
+	}, failed)
+}
+
+func TestFileTree_PermissionError(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("Permissions are not checked for root")
+	}
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+	assert.NoError(t, os.Chmod(filepath.Join(tempDir, "B"), 0))
+	defer os.Chmod(filepath.Join(tempDir, "B"), 0755)
+
+	root := storage.NewFileURI(tempDir)
+	branch, _ := storage.Child(root, "B")
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	tree.OnError = func(fyne.URI, error) {}
+	tree.OpenAllBranches()
+
+	err := tree.BranchError(branch.String())
+	assert.True(t, errors.Is(err, os.ErrPermission))
+	assert.Equal(t, "Permission denied", errorMessage(err))
+	assert.Equal(t, lockIcon, errorIcon(err))
+}
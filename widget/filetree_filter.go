@@ -0,0 +1,198 @@
+package widget
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+)
+
+// NewAndFileFilter returns a filter that matches URIs matched by all of the filters.
+func NewAndFileFilter(filters ...storage.FileFilter) storage.FileFilter {
+	return andFilter(filters)
+}
+
+// NewOrFileFilter returns a filter that matches URIs matched by any of the filters.
+func NewOrFileFilter(filters ...storage.FileFilter) storage.FileFilter {
+	return orFilter(filters)
+}
+
+// NewNotFileFilter returns a filter that matches URIs not matched by the filter.
+// For example NewNotFileFilter(NewHiddenFileFilter()) shows only files that are not hidden.
+func NewNotFileFilter(filter storage.FileFilter) storage.FileFilter {
+	return &notFilter{filter: filter}
+}
+
+// NewDirectoryFileFilter returns a filter that matches URIs that can be listed, such as directories.
+func NewDirectoryFileFilter() storage.FileFilter {
+	return directoryFilter{}
+}
+
+// NewGitIgnoreFileFilter returns a filter that matches URIs ignored by the git repository containing the
+// folder, using its .gitignore files and exclude file. The repository's .git folder is also matched.
+// To hide ignored files use NewNotFileFilter(filter).
+func NewGitIgnoreFileFilter(folder fyne.URI) (storage.FileFilter, error) {
+	if folder.Scheme() != "file" {
+		return nil, errGitNotFound
+	}
+	repo, err := findGitRepository(folder.Path())
+	if err != nil {
+		return nil, err
+	}
+
+	return &gitIgnoreFilter{
+		root:   repo.workDir,
+		ignore: newGitIgnore(repo.workDir, filepath.Join(repo.gitDir, "info", "exclude")),
+	}, nil
+}
+
+// NewGlobFileFilter returns a filter that matches URIs with names matching any of the glob patterns,
+// such as "*.go". A pattern containing a slash is matched against the end of the path instead,
+// and "**" in these patterns matches any number of folders.
+func NewGlobFileFilter(patterns ...string) storage.FileFilter {
+	f := &globFilter{}
+	for _, p := range patterns {
+		pathPattern := strings.Contains(p, "/")
+		expr := "^" + globToRegexp(p) + "$"
+		if pathPattern {
+			expr = "(?:^|/)" + globToRegexp(strings.TrimPrefix(p, "/")) + "$"
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			fyne.LogError("Invalid glob pattern "+p, err)
+			continue
+		}
+
+		if pathPattern {
+			f.paths = append(f.paths, re)
+		} else {
+			f.names = append(f.names, re)
+		}
+	}
+	return f
+}
+
+// NewHiddenFileFilter returns a filter that matches hidden files, which have names starting with a dot.
+// To hide them use NewNotFileFilter(NewHiddenFileFilter()).
+func NewHiddenFileFilter() storage.FileFilter {
+	return hiddenFilter{}
+}
+
+// NewModifiedFileFilter returns a filter that matches local files last modified between the given times.
+// A zero time leaves that end of the range open. URIs that are not local files are not matched.
+func NewModifiedFileFilter(after, before time.Time) storage.FileFilter {
+	return &statFilter{match: func(info os.FileInfo) bool {
+		modified := info.ModTime()
+		return (after.IsZero() || !modified.Before(after)) && (before.IsZero() || !modified.After(before))
+	}}
+}
+
+// NewSizeFileFilter returns a filter that matches local files with a size in bytes from min to max, inclusive.
+// A max of zero or less leaves the size unlimited. URIs that are not local files are not matched.
+func NewSizeFileFilter(min, max int64) storage.FileFilter {
+	return &statFilter{match: func(info os.FileInfo) bool {
+		return info.Size() >= min && (max <= 0 || info.Size() <= max)
+	}}
+}
+
+type andFilter []storage.FileFilter
+
+func (f andFilter) Matches(u fyne.URI) bool {
+	for _, filter := range f {
+		if !filter.Matches(u) {
+			return false
+		}
+	}
+	return true
+}
+
+type orFilter []storage.FileFilter
+
+func (f orFilter) Matches(u fyne.URI) bool {
+	for _, filter := range f {
+		if filter.Matches(u) {
+			return true
+		}
+	}
+	return false
+}
+
+type notFilter struct {
+	filter storage.FileFilter
+}
+
+func (f *notFilter) Matches(u fyne.URI) bool {
+	return !f.filter.Matches(u)
+}
+
+type directoryFilter struct{}
+
+func (directoryFilter) Matches(u fyne.URI) bool {
+	listable, _ := storage.CanList(u)
+	return listable
+}
+
+type gitIgnoreFilter struct {
+	root   string
+	ignore *gitIgnore
+}
+
+func (f *gitIgnoreFilter) Matches(u fyne.URI) bool {
+	if u.Scheme() != "file" {
+		return false
+	}
+	rel, err := filepath.Rel(f.root, filepath.FromSlash(u.Path()))
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == ".git" || strings.HasPrefix(rel, ".git/") {
+		return true
+	}
+
+	info, err := os.Stat(u.Path())
+	return f.ignore.Ignored(rel, err == nil && info.IsDir())
+}
+
+type globFilter struct {
+	names, paths []*regexp.Regexp
+}
+
+func (f *globFilter) Matches(u fyne.URI) bool {
+	name := u.Name()
+	for _, re := range f.names {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	for _, re := range f.paths {
+		if re.MatchString(u.Path()) {
+			return true
+		}
+	}
+	return false
+}
+
+type hiddenFilter struct{}
+
+func (hiddenFilter) Matches(u fyne.URI) bool {
+	name := u.Name()
+	return strings.HasPrefix(name, ".") && name != "." && name != ".."
+}
+
+// statFilter matches local files using their file information.
+type statFilter struct {
+	match func(os.FileInfo) bool
+}
+
+func (f *statFilter) Matches(u fyne.URI) bool {
+	if u.Scheme() != "file" {
+		return false
+	}
+	info, err := os.Stat(u.Path())
+	return err == nil && f.match(info)
+}
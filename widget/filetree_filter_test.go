@@ -0,0 +1,97 @@
+package widget
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+)
+
+func TestFileFilters(t *testing.T) {
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+	writeFile(t, tempDir, ".hidden", "hidden")
+	writeFile(t, tempDir, "A/large.bin", "0123456789")
+	old := time.Now().Add(-48 * time.Hour)
+	assert.NoError(t, os.Chtimes(filepath.Join(tempDir, "B", "C.txt"), old, old))
+
+	uri := func(name string) fyne.URI {
+		return storage.NewFileURI(filepath.Join(tempDir, name))
+	}
+	hidden := NewHiddenFileFilter()
+	assert.True(t, hidden.Matches(uri(".hidden")))
+	assert.False(t, hidden.Matches(uri("A")))
+
+	glob := NewGlobFileFilter("*.bin", "B/**/D.*")
+	assert.True(t, glob.Matches(uri("A/large.bin")))
+	assert.True(t, glob.Matches(uri("B/D.txt")))
+	assert.False(t, glob.Matches(uri("B/C.txt")))
+
+	size := NewSizeFileFilter(5, 0)
+	assert.True(t, size.Matches(uri("A/large.bin")))
+	assert.False(t, size.Matches(uri("B/C.txt")))
+	assert.True(t, NewSizeFileFilter(0, 1).Matches(uri("B/C.txt")))
+
+	modified := NewModifiedFileFilter(time.Now().Add(-time.Hour), time.Time{})
+	assert.True(t, modified.Matches(uri("B/D.txt")))
+	assert.False(t, modified.Matches(uri("B/C.txt")))
+
+	dirs := NewDirectoryFileFilter()
+	assert.True(t, dirs.Matches(uri("A")))
+	assert.False(t, dirs.Matches(uri("B/C.txt")))
+
+	combined := NewAndFileFilter(NewNotFileFilter(hidden), NewOrFileFilter(dirs, glob))
+	assert.True(t, combined.Matches(uri("A")))
+	assert.True(t, combined.Matches(uri("A/large.bin")))
+	assert.False(t, combined.Matches(uri(".hidden")))
+	assert.False(t, combined.Matches(uri("B/C.txt")))
+}
+
+func TestFileFilters_GitIgnore(t *testing.T) {
+	repo := createGitRepo(t)
+	defer os.RemoveAll(repo)
+	writeFile(t, repo, "debug.log", "log")
+	writeFile(t, repo, "build/out.bin", "binary")
+
+	root := storage.NewFileURI(repo)
+	filter, err := NewGitIgnoreFileFilter(root)
+	assert.NoError(t, err)
+	uri := func(name string) fyne.URI {
+		return storage.NewFileURI(filepath.Join(repo, name))
+	}
+	assert.True(t, filter.Matches(uri("debug.log")))
+	assert.True(t, filter.Matches(uri("build")))
+	assert.True(t, filter.Matches(uri("build/out.bin")))
+	assert.True(t, filter.Matches(uri(".git")))
+	assert.False(t, filter.Matches(uri("src")))
+	assert.False(t, filter.Matches(uri("modified.txt")))
+
+	_, err = NewGitIgnoreFileFilter(storage.NewFileURI(os.TempDir()))
+	assert.Error(t, err)
+}
+
+func TestFileTree_FilterLeavesOnly(t *testing.T) {
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	tree.Filter = NewGlobFileFilter("D.*")
+	tree.OpenAllBranches()
+	assert.Empty(t, tree.ChildUIDs(tree.Root))
+
+	tree = NewFileTree(root)
+	tree.Filter = NewGlobFileFilter("D.*")
+	tree.FilterLeavesOnly = true
+	tree.OpenAllBranches()
+	branchB, _ := storage.Child(root, "B")
+	leaf, _ := storage.Child(branchB, "D.txt")
+	assert.Equal(t, 2, len(tree.ChildUIDs(tree.Root)))
+	assert.Equal(t, []string{leaf.String()}, tree.ChildUIDs(branchB.String()))
+}
@@ -0,0 +1,129 @@
+package widget
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// FileIconProvider chooses the icons shown for the nodes of a FileTree.
+type FileIconProvider interface {
+	// Icon returns the icon for a URI, or nil to show the standard folder or file icon.
+	// Branch is true if the URI is a folder, and open is true if that folder is expanded.
+	Icon(u fyne.URI, branch, open bool) fyne.Resource
+}
+
+// FileIconMap is a FileIconProvider that looks up icons by URI, name, extension and MIME type, in that order.
+// Extensions and MIME types are only used for files.
+type FileIconMap struct {
+	// URIs maps the string form of a URI to its icon.
+	URIs map[string]fyne.Resource
+	// Names maps a file or folder name, such as ".git", to its icon.
+	Names map[string]fyne.Resource
+	// Extensions maps a lower case extension including the dot, such as ".go", to its icon.
+	Extensions map[string]fyne.Resource
+	// MimeTypes maps a MIME type, such as "text/html", or a group of types, such as "image/*", to its icon.
+	MimeTypes map[string]fyne.Resource
+}
+
+// NewFileIconMap creates a FileIconMap with icons for common special folders, archives and executables.
+// The maps can be changed to add or replace icons.
+func NewFileIconMap() *FileIconMap {
+	return &FileIconMap{
+		URIs: make(map[string]fyne.Resource),
+		Names: map[string]fyne.Resource{
+			".git":         theme.HistoryIcon(),
+			".github":      theme.SettingsIcon(),
+			".idea":        theme.SettingsIcon(),
+			".vscode":      theme.SettingsIcon(),
+			"node_modules": theme.StorageIcon(),
+			"vendor":       theme.StorageIcon(),
+		},
+		Extensions: map[string]fyne.Resource{
+			".bat": theme.ComputerIcon(),
+			".exe": theme.ComputerIcon(),
+			".sh":  theme.ComputerIcon(),
+		},
+		MimeTypes: map[string]fyne.Resource{
+			"application/gzip":             theme.StorageIcon(),
+			"application/x-7z-compressed":  theme.StorageIcon(),
+			"application/x-bzip2":          theme.StorageIcon(),
+			"application/x-gzip":           theme.StorageIcon(),
+			"application/x-tar":            theme.StorageIcon(),
+			"application/x-xz":             theme.StorageIcon(),
+			"application/zip":              theme.StorageIcon(),
+			"application/x-zip-compressed": theme.StorageIcon(),
+		},
+	}
+}
+
+// Icon returns the icon for a URI from the maps, or nil if none of them contain it.
+//
+// Implements: FileIconProvider
+func (m *FileIconMap) Icon(u fyne.URI, branch, _ bool) fyne.Resource {
+	if r, ok := m.URIs[u.String()]; ok {
+		return r
+	}
+	if r, ok := m.Names[u.Name()]; ok {
+		return r
+	}
+	if branch {
+		return nil
+	}
+	if r, ok := m.Extensions[strings.ToLower(u.Extension())]; ok {
+		return r
+	}
+	if len(m.MimeTypes) == 0 {
+		return nil
+	}
+
+	var mimeType string
+	if metadata := fileMetadata(u); metadata != nil {
+		mimeType = metadata.MimeType
+	} else {
+		mimeType = u.MimeType()
+	}
+	mimeType = strings.Split(mimeType, ";")[0]
+	if r, ok := m.MimeTypes[mimeType]; ok {
+		return r
+	}
+	if slash := strings.Index(mimeType, "/"); slash != -1 {
+		return m.MimeTypes[mimeType[:slash]+"/*"]
+	}
+	return nil
+}
+
+// icon returns the icon from the IconProvider for a URI, or nil if the standard icon should be used.
+func (t *FileTree) icon(u fyne.URI, branch, open bool) fyne.Resource {
+	if p := t.IconProvider; p != nil {
+		return p.Icon(u, branch, open)
+	}
+	return nil
+}
+
+// label returns the text shown for a node, which is its name, the name given to a root of a workspace
+// or the full URI for the root unless a Label function has been set.
+func (t *FileTree) label(id string, u fyne.URI) string {
+	return t.labelWith(t.Label, id, u)
+}
+
+// labelWith returns the text shown for a node using the given Label function,
+// so that a search in the background can use the function that was set when it started.
+func (t *FileTree) labelWith(label func(fyne.URI) string, id string, u fyne.URI) string {
+	if f := label; f != nil {
+		if l := f(u); l != "" {
+			return l
+		}
+	}
+	t.cacheLock.Lock()
+	name := t.rootNames[id]
+	t.cacheLock.Unlock()
+	if name != "" {
+		return name
+	}
+	if id == t.Root {
+		return id
+	}
+	return u.Name()
+}
@@ -0,0 +1,75 @@
+package widget
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+)
+
+func TestFileIconMap(t *testing.T) {
+	icons := NewFileIconMap()
+	icons.Extensions[".go"] = theme.DocumentIcon()
+	icons.MimeTypes["image/*"] = theme.FileImageIcon()
+
+	root := storage.NewFileURI("/project")
+	git, _ := storage.Child(root, ".git")
+	code, _ := storage.Child(root, "MAIN.GO")
+	picture, _ := storage.Child(root, "logo.png")
+	archive, _ := storage.Child(root, "release.zip")
+	other, _ := storage.Child(root, "notes.go")
+
+	assert.Equal(t, theme.HistoryIcon(), icons.Icon(git, true, false))
+	assert.Equal(t, theme.DocumentIcon(), icons.Icon(code, false, false))
+	assert.Equal(t, theme.FileImageIcon(), icons.Icon(picture, false, false))
+	assert.Equal(t, theme.StorageIcon(), icons.Icon(archive, false, false))
+	assert.Nil(t, icons.Icon(root, true, true))
+	assert.Nil(t, icons.Icon(other, true, false)) // extensions are not used for folders
+
+	icons.URIs[other.String()] = theme.HomeIcon()
+	assert.Equal(t, theme.HomeIcon(), icons.Icon(other, true, false))
+}
+
+func TestFileTree_IconsAndLabels(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	icons := NewFileIconMap()
+	branchA, _ := storage.Child(root, "A")
+	icons.URIs[branchA.String()] = theme.HomeIcon()
+
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	tree.IconProvider = icons
+	tree.Label = func(u fyne.URI) string {
+		if u.String() == root.String() {
+			return "Project"
+		}
+		return ""
+	}
+	tree.OpenAllBranches()
+	window := test.NewWindow(tree)
+	defer window.Close()
+	waitForFileTree(t, tree)
+	window.Resize(fyne.NewSize(300, 300))
+	waitForFileTree(t, tree)
+
+	node := findFileTreeNode(tree, branchA.String())
+	assert.True(t, node.customIcon.Visible())
+	assert.False(t, node.icon.Visible())
+	assert.Equal(t, theme.HomeIcon(), node.customIcon.Resource)
+	assert.Equal(t, "A", node.label.Text)
+
+	node = findFileTreeNode(tree, root.String())
+	assert.False(t, node.customIcon.Visible())
+	assert.True(t, node.icon.Visible())
+	assert.Equal(t, "Project", node.label.Text)
+}
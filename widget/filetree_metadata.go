@@ -0,0 +1,115 @@
+package widget
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// fileMetadataCacheTime is how long metadata is reused before it is looked up again.
+// Sorting compares each URI many times, so this avoids reading the same information repeatedly.
+const fileMetadataCacheTime = time.Second
+
+// fileMetadataCacheSize is the number of URIs that metadata is kept for before discarding the least recently used.
+const fileMetadataCacheSize = 10000
+
+// FileMetadata is information about a URI that is shown in the columns of a FileTreeTable and used for sorting.
+type FileMetadata struct {
+	// Directory is true if the URI can be listed.
+	Directory bool
+	// Size is the size in bytes, or -1 if it is not known.
+	Size int64
+	// Modified is the time of the last change, or zero if it is not known.
+	Modified time.Time
+	// MimeType describes the type of content, it may be empty.
+	MimeType string
+	// Symlink is true if the URI is a symbolic link, the other fields describe the item that it links to.
+	Symlink bool
+	// LinkTarget is the path that a symbolic link points to.
+	LinkTarget string
+}
+
+// FileMetadataProvider looks up the metadata of URIs for a URI scheme.
+type FileMetadataProvider interface {
+	// Metadata returns the information about a URI, or an error if it cannot be found.
+	Metadata(fyne.URI) (*FileMetadata, error)
+}
+
+// RegisterFileMetadataProvider sets the provider used to look up metadata for URIs with the given scheme.
+// A provider for the "file" scheme is registered by default, passing nil removes the provider for a scheme.
+func RegisterFileMetadataProvider(scheme string, provider FileMetadataProvider) {
+	metadataLock.Lock()
+	defer metadataLock.Unlock()
+	if provider == nil {
+		delete(metadataProviders, scheme)
+	} else {
+		metadataProviders[scheme] = provider
+	}
+	metadataCache.clear()
+}
+
+type metadataEntry struct {
+	metadata *FileMetadata
+	read     time.Time
+}
+
+var (
+	metadataLock      sync.Mutex
+	metadataCache     = newLRUCache(fileMetadataCacheSize) // of metadataEntry
+	metadataProviders = map[string]FileMetadataProvider{"file": localMetadataProvider{}}
+)
+
+// fileMetadata returns the metadata for a URI from the provider for its scheme,
+// or nil if there is no provider or the lookup fails. Results are reused for a short time.
+func fileMetadata(u fyne.URI) *FileMetadata {
+	key := u.String()
+	metadataLock.Lock()
+	cached, ok := metadataCache.get(key)
+	provider := metadataProviders[u.Scheme()]
+	metadataLock.Unlock()
+	if entry, _ := cached.(metadataEntry); ok && time.Since(entry.read) < fileMetadataCacheTime {
+		return entry.metadata
+	}
+	if provider == nil {
+		return nil
+	}
+
+	metadata, err := provider.Metadata(u)
+	if err != nil {
+		metadata = nil
+	}
+	metadataLock.Lock()
+	metadataCache.put(key, metadataEntry{metadata: metadata, read: time.Now()})
+	metadataLock.Unlock()
+	return metadata
+}
+
+type localMetadataProvider struct{}
+
+func (localMetadataProvider) Metadata(u fyne.URI) (*FileMetadata, error) {
+	info, err := os.Lstat(u.Path())
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &FileMetadata{Size: -1}
+	if info.Mode()&os.ModeSymlink != 0 {
+		metadata.Symlink = true
+		metadata.LinkTarget, _ = os.Readlink(u.Path())
+		if info, err = os.Stat(u.Path()); err != nil {
+			return metadata, nil // the link is broken
+		}
+	}
+
+	metadata.Modified = info.ModTime()
+	if info.IsDir() {
+		metadata.Directory = true
+		metadata.MimeType = "inode/directory"
+	} else {
+		metadata.Size = info.Size()
+		metadata.MimeType = u.MimeType()
+	}
+	return metadata, nil
+}
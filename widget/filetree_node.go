@@ -0,0 +1,380 @@
+package widget
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// fileTreeNode is the content of each row in a FileTree.
+// It shows the icon and name of a URI and handles the pointer events used by file operations.
+type fileTreeNode struct {
+	widget.BaseWidget
+	tree *FileTree
+	id   widget.TreeNodeID
+
+	background    *canvas.Rectangle
+	dropIndicator *canvas.Rectangle
+	icon          fyne.CanvasObject
+	customIcon    *widget.Icon
+	label         *widget.Label
+	content       *fyne.Container
+
+	name, suffix, badge *canvas.Text
+	decorations         *fyne.Container
+	cells, trailing     *fyne.Container
+
+	match                *canvas.Rectangle
+	matchStart, matchEnd int
+
+	dragging bool
+	dragPos  fyne.Position
+	modifier desktop.Modifier
+}
+
+func newFileTreeNode(tree *FileTree, branch bool) *fileTreeNode {
+	var icon fyne.CanvasObject
+	if branch {
+		icon = widget.NewIcon(nil)
+	} else {
+		icon = widget.NewFileIcon(nil)
+	}
+	customIcon := widget.NewIcon(nil)
+	customIcon.Hide()
+	label := widget.NewLabel("Template Object")
+	background := canvas.NewRectangle(theme.FocusColor())
+	background.Hide()
+	dropIndicator := canvas.NewRectangle(color.Transparent)
+	dropIndicator.StrokeColor = theme.PrimaryColor()
+	dropIndicator.StrokeWidth = 2
+	dropIndicator.Hide()
+
+	// the name is drawn as text when it is coloured by a decoration, in place of the label
+	name := canvas.NewText("", theme.TextColor())
+	name.Hide()
+	suffix := canvas.NewText("", theme.DisabledTextColor())
+	badge := canvas.NewText("", theme.TextColor())
+	badge.TextStyle.Bold = true
+	decorations := container.NewPadded(container.NewHBox(suffix, badge))
+	decorations.Hide()
+	cells := container.New(&fileTreeColumnsLayout{tree: tree})
+	cells.Hide()
+	trailing := container.NewHBox(decorations, cells)
+	trailing.Hide()
+	match := canvas.NewRectangle(matchColor())
+	match.Hide()
+
+	n := &fileTreeNode{
+		tree:          tree,
+		background:    background,
+		dropIndicator: dropIndicator,
+		icon:          icon,
+		customIcon:    customIcon,
+		label:         label,
+		name:          name,
+		suffix:        suffix,
+		badge:         badge,
+		decorations:   decorations,
+		cells:         cells,
+		trailing:      trailing,
+		match:         match,
+		matchStart:    -1,
+	}
+	center := container.NewMax(container.NewWithoutLayout(match), label, container.NewPadded(name))
+	n.content = container.NewBorder(nil, nil, container.NewMax(icon, customIcon), trailing, center)
+	n.ExtendBaseWidget(n)
+	tree.nodes = append(tree.nodes, n)
+	return n
+}
+
+// CreateRenderer is a private method to Fyne which links this widget to its renderer.
+func (n *fileTreeNode) CreateRenderer() fyne.WidgetRenderer {
+	return &fileTreeNodeRenderer{node: n}
+}
+
+// Dragged is called when the user drags this node, passing its URI, or the selected URIs if it is selected,
+// to the drop target below the pointer. On mobile devices dragging scrolls the tree instead, as a touch
+// that moves would otherwise always start moving files.
+//
+// Implements: fyne.Draggable
+func (n *fileTreeNode) Dragged(ev *fyne.DragEvent) {
+	if fyne.CurrentDevice().IsMobile() {
+		n.tree.scrollDragged(ev)
+		return
+	}
+	if isPlaceholderNode(n.id) || n.tree.isWorkspaceRoot(n.id) {
+		return
+	}
+	n.dragging = true
+	n.dragPos = ev.AbsolutePosition
+	if n.dragPos.IsZero() { // some drivers only report the position relative to the start of the drag
+		start := fyne.CurrentApp().Driver().AbsolutePositionForObject(n).Add(ev.Position)
+		n.dragPos = start.Add(fyne.NewPos(ev.Dragged.DX, ev.Dragged.DY))
+	}
+	n.tree.dragMoved(n.id, n.dragPos)
+}
+
+// DragEnd is called when the user releases a drag, dropping the dragged URIs on the target below the pointer.
+// Dropping them on a folder of this tree moves them there if operations are enabled.
+//
+// Implements: fyne.Draggable
+func (n *fileTreeNode) DragEnd() {
+	if !n.dragging {
+		return
+	}
+	n.dragging = false
+	n.tree.dragEnded()
+}
+
+// MouseDown records the modifier keys held when the node is clicked, so that Tapped can extend the selection.
+//
+// Implements: desktop.Mouseable
+func (n *fileTreeNode) MouseDown(ev *desktop.MouseEvent) {
+	n.modifier = ev.Modifier
+}
+
+// MouseUp is called when a mouse button is released over the node.
+//
+// Implements: desktop.Mouseable
+func (n *fileTreeNode) MouseUp(*desktop.MouseEvent) {
+}
+
+// Tapped selects this node in the tree and focuses the tree for keyboard selection.
+// Holding Shift selects the range from the last selected node, and Control (or Command) toggles the node.
+// Tapping an error node lists its branch again.
+//
+// Implements: fyne.Tappable
+func (n *fileTreeNode) Tapped(*fyne.PointEvent) {
+	if isLoadingNode(n.id) {
+		return
+	}
+	if isErrorNode(n.id) {
+		n.tree.Retry(n.id)
+		return
+	}
+	modifier := n.modifier
+	n.modifier = 0
+
+	t := n.tree
+	switch {
+	case modifier&desktop.ShiftModifier != 0:
+		t.SelectRange(t.currentAnchor(), n.id)
+	case modifier&(desktop.ControlModifier|desktop.SuperModifier) != 0:
+		t.ToggleSelected(n.id)
+	default:
+		t.Select(n.id)
+	}
+
+	if c := fyne.CurrentApp().Driver().CanvasForObject(t); c != nil {
+		c.Focus(t)
+	}
+}
+
+// TappedSecondary shows the file operations menu, if operations are enabled.
+//
+// Implements: fyne.SecondaryTappable
+func (n *fileTreeNode) TappedSecondary(ev *fyne.PointEvent) {
+	ops := n.tree.operations
+	if ops == nil || isPlaceholderNode(n.id) {
+		return
+	}
+	uri, err := n.tree.toURI(n.id)
+	if err != nil {
+		fyne.LogError("Unable to parse URI", err)
+		return
+	}
+
+	c := fyne.CurrentApp().Driver().CanvasForObject(n)
+	menu := fyne.NewMenu("", ops.menuItems(n, uri, n.tree.IsBranch(n.id))...)
+	widget.ShowPopUpMenuAtPosition(menu, c, ev.AbsolutePosition)
+}
+
+// setDropIndicator shows or hides the outline marking this node as the folder that dragged URIs will be dropped in.
+func (n *fileTreeNode) setDropIndicator(shown bool) {
+	if shown == n.dropIndicator.Visible() {
+		return
+	}
+	if shown {
+		n.dropIndicator.Show()
+	} else {
+		n.dropIndicator.Hide()
+	}
+}
+
+// setHighlighted shows or hides the background used to mark this node as part of a multiple selection.
+func (n *fileTreeNode) setHighlighted(highlighted bool) {
+	if highlighted == n.background.Visible() {
+		return
+	}
+	if highlighted {
+		n.background.Show()
+	} else {
+		n.background.Hide()
+	}
+}
+
+// setDecoration updates the colour of the name and the badge and suffix shown after it.
+func (n *fileTreeNode) setDecoration(d *FileDecoration) {
+	if d == nil {
+		d = &FileDecoration{}
+	}
+
+	if d.TextColor == nil {
+		n.name.Hide()
+		n.label.Show()
+	} else {
+		n.name.Text = n.label.Text
+		n.name.Color = d.TextColor
+		n.name.Show()
+		n.label.Hide()
+	}
+
+	n.suffix.Text = d.Suffix
+	n.badge.Text = d.Badge
+	n.badge.Color = d.BadgeColor
+	if n.badge.Color == nil {
+		n.badge.Color = theme.TextColor()
+	}
+	n.suffix.Hidden = d.Suffix == ""
+	n.badge.Hidden = d.Badge == ""
+	n.decorations.Hidden = n.suffix.Hidden && n.badge.Hidden
+	n.refreshTrailing()
+}
+
+// setColumns fills the detail columns of a FileTreeTable for the URI, which is nil for a loading node.
+func (n *fileTreeNode) setColumns(u fyne.URI) {
+	table := n.tree.table
+	if table == nil {
+		return
+	}
+
+	for len(n.cells.Objects) < len(table.columns) {
+		n.cells.Add(canvas.NewText("", theme.TextColor()))
+	}
+	n.cells.Objects = n.cells.Objects[:len(table.columns)]
+	var metadata *FileMetadata
+	if u != nil {
+		metadata = fileMetadata(u)
+	}
+	for i, col := range table.columns {
+		cell := n.cells.Objects[i].(*canvas.Text)
+		cell.Text = ""
+		if u != nil && col.Value != nil {
+			cell.Text = col.Value(u, metadata)
+		}
+		cell.Color = theme.TextColor()
+		cell.Alignment = col.Alignment
+	}
+	n.cells.Show()
+	n.refreshTrailing()
+}
+
+// refreshTrailing shows the area after the name if it has decorations or columns, and lays out the node again.
+func (n *fileTreeNode) refreshTrailing() {
+	n.trailing.Hidden = n.decorations.Hidden && n.cells.Hidden
+	n.content.Refresh()
+}
+
+// setMatch highlights the given byte range of the name, or removes the highlight if start is -1.
+func (n *fileTreeNode) setMatch(start, end int) {
+	n.matchStart, n.matchEnd = start, end
+	n.layoutMatch()
+}
+
+// layoutMatch positions the highlight over the matched part of the name.
+func (n *fileTreeNode) layoutMatch() {
+	text := n.label.Text
+	if n.matchStart < 0 || n.matchEnd > len(text) {
+		n.match.Hide()
+		return
+	}
+
+	size, style := theme.TextSize(), n.label.TextStyle
+	before := fyne.MeasureText(text[:n.matchStart], size, style)
+	matched := fyne.MeasureText(text[n.matchStart:n.matchEnd], size, style)
+	n.match.Move(fyne.NewPos(theme.Padding()+before.Width, theme.Padding()))
+	n.match.Resize(matched)
+	n.match.Show()
+}
+
+// promptName shows an entry over the label of this node for the user to type a name.
+// The callback is only called if a non-empty name is submitted.
+func (n *fileTreeNode) promptName(initial string, done func(string)) {
+	c := fyne.CurrentApp().Driver().CanvasForObject(n)
+	entry := widget.NewEntry()
+	entry.SetText(initial)
+
+	var pop *widget.PopUp
+	entry.OnSubmitted = func(name string) {
+		pop.Hide()
+		if name != "" {
+			done(name)
+		}
+	}
+	pop = widget.NewPopUp(entry, c)
+	pop.Resize(fyne.NewSize(n.label.Size().Width, entry.MinSize().Height))
+	pop.ShowAtPosition(fyne.CurrentApp().Driver().AbsolutePositionForObject(n.label))
+	c.Focus(entry)
+}
+
+type fileTreeNodeRenderer struct {
+	node *fileTreeNode
+}
+
+func (r *fileTreeNodeRenderer) Destroy() {
+}
+
+func (r *fileTreeNodeRenderer) Layout(size fyne.Size) {
+	r.node.background.Resize(size)
+	r.node.dropIndicator.Resize(size)
+	r.node.content.Resize(size)
+	r.node.layoutMatch()
+}
+
+func (r *fileTreeNodeRenderer) MinSize() fyne.Size {
+	return r.node.content.MinSize()
+}
+
+func (r *fileTreeNodeRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.node.background, r.node.content, r.node.dropIndicator}
+}
+
+func (r *fileTreeNodeRenderer) Refresh() {
+	r.node.background.FillColor = theme.FocusColor()
+	r.node.background.Refresh()
+	r.node.dropIndicator.StrokeColor = theme.PrimaryColor()
+	r.node.dropIndicator.Refresh()
+	r.node.suffix.Color = theme.DisabledTextColor()
+	r.node.match.FillColor = matchColor()
+	r.node.match.Refresh()
+	r.node.content.Refresh()
+}
+
+// nodeAtPosition returns the node currently shown at an absolute position on the canvas, if there is one.
+func (t *FileTree) nodeAtPosition(pos fyne.Position) *fileTreeNode {
+	d := fyne.CurrentApp().Driver()
+	for _, n := range t.nodes {
+		// nodes that are not currently shown are not found on the canvas, which reports them at the origin
+		nodePos := d.AbsolutePositionForObject(n)
+		if nodePos.IsZero() {
+			continue
+		}
+
+		size := n.Size()
+		if pos.X >= nodePos.X && pos.Y >= nodePos.Y && pos.X < nodePos.X+size.Width && pos.Y < nodePos.Y+size.Height {
+			return n
+		}
+	}
+	return nil
+}
+
+// matchColor returns a translucent version of the primary colour, to highlight text without hiding it.
+func matchColor() color.Color {
+	r, g, b, _ := theme.PrimaryColor().RGBA()
+	return color.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: 0x66}
+}
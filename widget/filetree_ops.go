@@ -0,0 +1,421 @@
+package widget
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/storage/repository"
+	"fyne.io/fyne/v2/widget"
+)
+
+var (
+	errFileExists  = errors.New("a file with that name already exists")
+	errInvalidName = errors.New("a name cannot be empty, \".\" or \"..\", or contain a path separator")
+	errMoveInto    = errors.New("a folder cannot be moved inside itself")
+	errNoTrash     = errors.New("no trash has been configured")
+)
+
+// FileOperation identifies a change to the file system that FileTreeOperations can make.
+type FileOperation int
+
+const (
+	// FileOperationNewFile creates an empty file.
+	FileOperationNewFile FileOperation = iota
+	// FileOperationNewFolder creates an empty folder.
+	FileOperationNewFolder
+	// FileOperationRename changes the name of a file or folder.
+	FileOperationRename
+	// FileOperationDelete permanently deletes a file or folder and its contents.
+	FileOperationDelete
+	// FileOperationTrash moves a file or folder to the trash.
+	FileOperationTrash
+	// FileOperationDuplicate copies a file or folder next to the original.
+	FileOperationDuplicate
+	// FileOperationMove moves a file or folder into another folder.
+	FileOperationMove
+)
+
+// FileTreeOperations adds actions that change the files shown in a FileTree.
+// They are available from a context menu on each node and folders accept nodes dragged on to them.
+type FileTreeOperations struct {
+	// Confirm is called before each operation and it will only happen if the callback is passed true.
+	// The source is the URI being changed, or the parent folder for new items.
+	// The target is the URI that will result, or nil when deleting.
+	// If Confirm is nil all operations are allowed.
+	Confirm func(op FileOperation, source, target fyne.URI, callback func(bool))
+	// MoveToTrash is used to trash files, if it is nil only permanent delete is offered.
+	MoveToTrash func(fyne.URI) error
+	// OnError is called if an operation fails, if it is nil the error will be logged.
+	OnError func(op FileOperation, err error)
+
+	tree *FileTree
+}
+
+// EnableOperations turns on file operations for this FileTree.
+// The returned operations can be configured, or used to perform operations directly.
+func (t *FileTree) EnableOperations() *FileTreeOperations {
+	if t.operations == nil {
+		t.operations = &FileTreeOperations{tree: t}
+	}
+	return t.operations
+}
+
+// Delete permanently removes the file or folder at the URI, including the contents of a folder.
+func (o *FileTreeOperations) Delete(u fyne.URI) {
+	o.run(FileOperationDelete, u, nil, func() error {
+		return deleteURI(u)
+	}, u)
+}
+
+// Duplicate copies the file or folder at the URI to a new name in the same folder.
+func (o *FileTreeOperations) Duplicate(u fyne.URI) {
+	parent, err := storage.Parent(u)
+	if err != nil {
+		o.handleError(FileOperationDuplicate, err)
+		return
+	}
+	target, err := copyName(parent, u.Name())
+	if err != nil {
+		o.handleError(FileOperationDuplicate, err)
+		return
+	}
+
+	o.run(FileOperationDuplicate, u, target, func() error {
+		return copyURI(u, target)
+	}, u)
+}
+
+// Move moves the file or folder at the URI into the folder.
+func (o *FileTreeOperations) Move(u, folder fyne.URI) {
+	target, err := storage.Child(folder, u.Name())
+	if err != nil {
+		o.handleError(FileOperationMove, err)
+		return
+	}
+	id := strings.TrimSuffix(u.String(), "/")
+	if dest := folder.String(); dest == id || strings.HasPrefix(dest, id+"/") {
+		o.handleError(FileOperationMove, errMoveInto)
+		return
+	}
+
+	o.run(FileOperationMove, u, target, func() error {
+		return moveURI(u, target)
+	}, u)
+}
+
+// NewFile creates an empty file with the given name in the folder.
+func (o *FileTreeOperations) NewFile(folder fyne.URI, name string) {
+	o.create(FileOperationNewFile, folder, name, func(u fyne.URI) error {
+		w, err := storage.Writer(u)
+		if err != nil {
+			return err
+		}
+		return w.Close()
+	})
+}
+
+// NewFolder creates an empty folder with the given name inside the folder.
+func (o *FileTreeOperations) NewFolder(folder fyne.URI, name string) {
+	o.create(FileOperationNewFolder, folder, name, storage.CreateListable)
+}
+
+// Rename changes the name of the file or folder at the URI.
+// The name must not be empty, "." or "..", or contain a path separator.
+func (o *FileTreeOperations) Rename(u fyne.URI, name string) {
+	if err := checkName(name); err != nil {
+		o.handleError(FileOperationRename, err)
+		return
+	}
+	parent, err := storage.Parent(u)
+	if err != nil {
+		o.handleError(FileOperationRename, err)
+		return
+	}
+	target, err := storage.Child(parent, name)
+	if err != nil {
+		o.handleError(FileOperationRename, err)
+		return
+	}
+
+	o.run(FileOperationRename, u, target, func() error {
+		return moveURI(u, target)
+	}, u)
+}
+
+// Trash moves the file or folder at the URI to the trash using MoveToTrash.
+func (o *FileTreeOperations) Trash(u fyne.URI) {
+	o.run(FileOperationTrash, u, nil, func() error {
+		if o.MoveToTrash == nil {
+			return errNoTrash
+		}
+		return o.MoveToTrash(u)
+	}, u)
+}
+
+func (o *FileTreeOperations) create(op FileOperation, folder fyne.URI, name string, create func(fyne.URI) error) {
+	if err := checkName(name); err != nil {
+		o.handleError(op, err)
+		return
+	}
+	target, err := storage.Child(folder, name)
+	if err != nil {
+		o.handleError(op, err)
+		return
+	}
+
+	o.run(op, folder, target, func() error {
+		if exists, _ := storage.Exists(target); exists {
+			return errFileExists
+		}
+		return create(target)
+	})
+}
+
+func (o *FileTreeOperations) handleError(op FileOperation, err error) {
+	if f := o.OnError; f != nil {
+		f(op, err)
+		return
+	}
+	fyne.LogError("File operation failed", err)
+}
+
+func (o *FileTreeOperations) menuItems(n *fileTreeNode, u fyne.URI, branch bool) (items []*fyne.MenuItem) {
+	if branch {
+		items = append(items,
+			fyne.NewMenuItem("New File", func() {
+				n.promptName("", func(name string) { o.NewFile(u, name) })
+			}),
+			fyne.NewMenuItem("New Folder", func() {
+				n.promptName("", func(name string) { o.NewFolder(u, name) })
+			}))
+	}
+	if o.tree.isRoot(n.id) {
+		return items
+	}
+	if branch {
+		items = append(items, fyne.NewMenuItemSeparator())
+	}
+
+	items = append(items,
+		fyne.NewMenuItem("Rename", func() {
+			n.promptName(u.Name(), func(name string) {
+				if name != u.Name() {
+					o.Rename(u, name)
+				}
+			})
+		}),
+		fyne.NewMenuItem("Duplicate", func() { o.Duplicate(u) }))
+	if o.MoveToTrash != nil {
+		return append(items,
+			fyne.NewMenuItem("Move to Trash", func() { o.Trash(u) }),
+			fyne.NewMenuItem("Delete Permanently", func() { o.Delete(u) }))
+	}
+	return append(items, fyne.NewMenuItem("Delete", func() { o.Delete(u) }))
+}
+
+// run checks with Confirm before applying the operation, then lists the branches containing the target
+// and the other changed URIs again in the background.
+func (o *FileTreeOperations) run(op FileOperation, source, target fyne.URI, apply func() error, changed ...fyne.URI) {
+	perform := func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := apply(); err != nil {
+			o.handleError(op, err)
+		}
+		if exists, err := storage.Exists(source); err == nil && !exists {
+			o.tree.unselectRemoved(source)
+		}
+
+		if target != nil {
+			changed = append(changed, target)
+		}
+		var parents []widget.TreeNodeID
+		for _, u := range changed {
+			if parent, err := storage.Parent(u); err == nil {
+				parents = append(parents, o.tree.branchID(parent))
+			}
+		}
+		o.tree.reloadInBackground(parents)
+	}
+
+	if o.Confirm == nil {
+		perform(true)
+		return
+	}
+	o.Confirm(op, source, target, perform)
+}
+
+// checkName returns an error if the name would not refer to an item directly inside a folder.
+func checkName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\") {
+		return errInvalidName
+	}
+	return nil
+}
+
+// copyName finds an unused name in the folder for a copy of the named file.
+func copyName(folder fyne.URI, name string) (fyne.URI, error) {
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for i := 1; ; i++ {
+		copied := base + " copy" + ext
+		if i > 1 {
+			copied = fmt.Sprintf("%s copy %d%s", base, i, ext)
+		}
+
+		u, err := storage.Child(folder, copied)
+		if err != nil {
+			return nil, err
+		}
+		if exists, err := storage.Exists(u); err != nil || !exists {
+			return u, err
+		}
+	}
+}
+
+// copyURI copies a file, or a folder and all of its contents, to the target.
+func copyURI(source, target fyne.URI) error {
+	if exists, _ := storage.Exists(target); exists {
+		return errFileExists
+	}
+	if isSymlink(source) {
+		return copySymlink(source, target)
+	}
+	if listable, _ := storage.CanList(source); !listable {
+		return storage.Copy(source, target)
+	}
+
+	if err := storage.CreateListable(target); err != nil {
+		return err
+	}
+	children, err := storage.List(source)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		dest, err := storage.Child(target, child.Name())
+		if err != nil {
+			return err
+		}
+		if err = copyURI(child, dest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copySymlink creates a link at the target to the same place as the source link.
+// Relative links are made absolute if the target is in a different folder.
+func copySymlink(source, target fyne.URI) error {
+	dest, err := os.Readlink(source.Path())
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(source.Path()); !filepath.IsAbs(dest) && dir != filepath.Dir(target.Path()) {
+		dest = filepath.Join(dir, dest)
+	}
+	return os.Symlink(dest, target.Path())
+}
+
+// deleteURI deletes a file, or a folder and all of its contents. Symbolic links are deleted without
+// deleting what they link to.
+func deleteURI(u fyne.URI) error {
+	if listable, _ := storage.CanList(u); listable && !isSymlink(u) {
+		children, err := storage.List(u)
+		if err != nil {
+			return err
+		}
+		for _, child := range children {
+			if err = deleteURI(child); err != nil {
+				return err
+			}
+		}
+	}
+
+	return storage.Delete(u)
+}
+
+// isSameFileInOtherCase returns true if two local URIs differ only in the case of their names but are the same
+// file, as they are on a file system that ignores case. Renaming one to the other only changes the case.
+func isSameFileInOtherCase(source, target fyne.URI) bool {
+	if source.Scheme() != "file" || target.Scheme() != "file" || source.Path() == target.Path() ||
+		!strings.EqualFold(source.Path(), target.Path()) {
+		return false
+	}
+	sourceInfo, err := os.Lstat(source.Path())
+	if err != nil {
+		return false
+	}
+	targetInfo, err := os.Lstat(target.Path())
+	return err == nil && os.SameFile(sourceInfo, targetInfo)
+}
+
+// renameURI moves a URI in one step, returning repository.ErrOperationNotSupported if it cannot.
+// Local files are renamed, which also moves a folder with its contents, unless they are on different devices.
+// The generic move of other repositories copies the content, so it is only used for those that support moving.
+func renameURI(source, target fyne.URI) error {
+	if source.Scheme() != "file" || target.Scheme() != "file" {
+		return storage.Move(source, target)
+	}
+	err := os.Rename(source.Path(), target.Path())
+	if errors.Is(err, syscall.EXDEV) {
+		return repository.ErrOperationNotSupported
+	}
+	return err
+}
+
+// isSymlink returns true if the URI is a local symbolic link. File operations change the link itself,
+// rather than the file or folder that it links to.
+func isSymlink(u fyne.URI) bool {
+	if u.Scheme() != "file" {
+		return false
+	}
+	info, err := os.Lstat(u.Path())
+	return err == nil && info.Mode()&os.ModeSymlink != 0
+}
+
+// moveURI moves a file, or a folder and all of its contents, to the target. It is moved in one step if its
+// repository can, otherwise a folder is copied a child at a time and then deleted.
+func moveURI(source, target fyne.URI) error {
+	if exists, _ := storage.Exists(target); exists && !isSameFileInOtherCase(source, target) {
+		return errFileExists
+	}
+	if err := renameURI(source, target); err != repository.ErrOperationNotSupported {
+		return err
+	}
+	if isSymlink(source) {
+		if err := copySymlink(source, target); err != nil {
+			return err
+		}
+		return storage.Delete(source)
+	}
+	if listable, _ := storage.CanList(source); !listable {
+		return repository.GenericMove(source, target)
+	}
+
+	if err := storage.CreateListable(target); err != nil {
+		return err
+	}
+	children, err := storage.List(source)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		dest, err := storage.Child(target, child.Name())
+		if err != nil {
+			return err
+		}
+		if err = moveURI(child, dest); err != nil {
+			return err
+		}
+	}
+	return storage.Delete(source)
+}
@@ -0,0 +1,267 @@
+package widget
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestFileTreeOperations(t *testing.T) {
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	ops := tree.EnableOperations()
+	var errs []error
+	ops.OnError = func(_ FileOperation, err error) {
+		errs = append(errs, err)
+	}
+	branchA, _ := storage.Child(root, "A")
+	branchB, _ := storage.Child(root, "B")
+	leaf, _ := storage.Child(branchB, "C.txt")
+
+	ops.NewFile(branchA, "E.txt")
+	assert.FileExists(t, path.Join(tempDir, "A", "E.txt"))
+	ops.NewFolder(branchA, "F")
+	assert.DirExists(t, path.Join(tempDir, "A", "F"))
+	ops.NewFolder(branchA, "F")
+	assert.Equal(t, []error{errFileExists}, errs)
+
+	ops.Rename(leaf, "G.txt")
+	assert.NoFileExists(t, path.Join(tempDir, "B", "C.txt"))
+	content, err := ioutil.ReadFile(path.Join(tempDir, "B", "G.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "c", string(content))
+
+	ops.Duplicate(branchB)
+	ops.Duplicate(branchB)
+	assert.FileExists(t, path.Join(tempDir, "B copy", "G.txt"))
+	assert.FileExists(t, path.Join(tempDir, "B copy 2", "D.txt"))
+
+	ops.Move(branchB, branchA)
+	assert.NoDirExists(t, path.Join(tempDir, "B"))
+	assert.FileExists(t, path.Join(tempDir, "A", "B", "G.txt"))
+	ops.Move(branchA, branchA)
+	assert.Equal(t, errMoveInto, errs[len(errs)-1])
+
+	ops.Delete(branchA)
+	assert.NoDirExists(t, path.Join(tempDir, "A"))
+}
+
+func TestFileTreeOperations_RenameCase(t *testing.T) {
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	ops := tree.EnableOperations()
+	var errs []error
+	ops.OnError = func(_ FileOperation, err error) {
+		errs = append(errs, err)
+	}
+	branch, _ := storage.Child(root, "B")
+	leaf, _ := storage.Child(branch, "C.txt")
+	renamed, _ := storage.Child(branch, "c.txt")
+	other, _ := storage.Child(branch, "D.txt")
+
+	// a file system that ignores case reports the new name as existing, as a hard link does here
+	assert.NoError(t, os.Link(path.Join(tempDir, "B", "C.txt"), path.Join(tempDir, "B", "c.txt")))
+	assert.True(t, isSameFileInOtherCase(leaf, renamed))
+	assert.False(t, isSameFileInOtherCase(leaf, leaf))
+	assert.False(t, isSameFileInOtherCase(leaf, other))
+	assert.NoError(t, os.Remove(path.Join(tempDir, "B", "c.txt")))
+
+	ops.Rename(leaf, "c.txt")
+	assert.Empty(t, errs)
+	assert.FileExists(t, path.Join(tempDir, "B", "c.txt"))
+
+	// folders are moved in one step, rather than copied
+	before, err := os.Stat(path.Join(tempDir, "B"))
+	assert.NoError(t, err)
+	ops.Rename(branch, "E")
+	after, err := os.Stat(path.Join(tempDir, "E"))
+	assert.NoError(t, err)
+	assert.True(t, os.SameFile(before, after))
+	assert.Empty(t, errs)
+}
+
+func TestFileTreeOperations_InvalidName(t *testing.T) {
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	ops := tree.EnableOperations()
+	var errs []error
+	ops.OnError = func(_ FileOperation, err error) {
+		errs = append(errs, err)
+	}
+	branch, _ := storage.Child(root, "B")
+	leaf, _ := storage.Child(branch, "C.txt")
+
+	for _, name := range []string{"", ".", "..", "../escaped.txt", "sub/name.txt", "sub\\name.txt"} {
+		errs = nil
+		ops.Rename(leaf, name)
+		assert.Equal(t, []error{errInvalidName}, errs, name)
+		assert.FileExists(t, path.Join(tempDir, "B", "C.txt"))
+
+		errs = nil
+		ops.NewFile(branch, name)
+		assert.Equal(t, []error{errInvalidName}, errs, name)
+	}
+	assert.NoFileExists(t, path.Join(tempDir, "escaped.txt"))
+}
+
+func TestFileTreeOperations_Symlink(t *testing.T) {
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+	if err := os.Symlink(path.Join(tempDir, "B"), path.Join(tempDir, "A", "link")); err != nil {
+		t.Skip("Symbolic links are not supported", err)
+	}
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	ops := tree.EnableOperations()
+	var errs []error
+	ops.OnError = func(_ FileOperation, err error) {
+		errs = append(errs, err)
+	}
+	branchA, _ := storage.Child(root, "A")
+	link, _ := storage.Child(branchA, "link")
+
+	ops.Duplicate(link)
+	target, err := os.Readlink(path.Join(tempDir, "A", "link copy"))
+	assert.NoError(t, err)
+	assert.Equal(t, path.Join(tempDir, "B"), target)
+
+	ops.Move(link, root)
+	target, err = os.Readlink(path.Join(tempDir, "link"))
+	assert.NoError(t, err)
+	assert.Equal(t, path.Join(tempDir, "B"), target)
+	moved, _ := storage.Child(root, "link")
+
+	ops.Delete(moved)
+	_, err = os.Lstat(path.Join(tempDir, "link"))
+	assert.True(t, os.IsNotExist(err))
+	assert.FileExists(t, path.Join(tempDir, "B", "C.txt"))
+	assert.FileExists(t, path.Join(tempDir, "B", "D.txt"))
+	assert.Empty(t, errs)
+}
+
+func TestFileTreeOperations_Confirm(t *testing.T) {
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	ops := tree.EnableOperations()
+	branch, _ := storage.Child(root, "B")
+	leaf, _ := storage.Child(branch, "C.txt")
+
+	var trashed fyne.URI
+	ops.MoveToTrash = func(u fyne.URI) error {
+		trashed = u
+		return nil
+	}
+	var confirmed FileOperation
+	ops.Confirm = func(op FileOperation, source, target fyne.URI, callback func(bool)) {
+		confirmed = op
+		assert.Equal(t, leaf, source)
+		assert.Nil(t, target)
+		callback(false)
+	}
+	ops.Delete(leaf)
+	assert.Equal(t, FileOperationDelete, confirmed)
+	assert.FileExists(t, path.Join(tempDir, "B", "C.txt"))
+
+	ops.Confirm = nil
+	ops.Trash(leaf)
+	assert.Equal(t, leaf, trashed)
+}
+
+func TestFileTreeOperations_Menu(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	tree.EnableOperations()
+	tree.OpenAllBranches()
+	window := test.NewWindow(tree)
+	defer window.Close()
+	waitForFileTree(t, tree)
+	window.Resize(fyne.NewSize(300, 300))
+	waitForFileTree(t, tree)
+
+	leaf, _ := storage.Child(root, "B")
+	leaf, _ = storage.Child(leaf, "C.txt")
+	node := findFileTreeNode(tree, leaf.String())
+	test.TapSecondary(node)
+	assert.NotNil(t, window.Canvas().Overlays().Top())
+	window.Canvas().Overlays().Top().Hide()
+
+	items := tree.operations.menuItems(node, leaf, false)
+	assert.Equal(t, 3, len(items))
+	assert.Equal(t, 6, len(tree.operations.menuItems(node, leaf, true)))
+
+	items[0].Action() // Rename
+	entry := window.Canvas().Focused().(*widget.Entry)
+	entry.SetText("E.txt")
+	entry.OnSubmitted(entry.Text)
+	assert.FileExists(t, path.Join(tempDir, "B", "E.txt"))
+}
+
+func TestFileTreeOperations_Drag(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	tree.EnableOperations()
+	tree.OpenAllBranches()
+	window := test.NewWindow(tree)
+	defer window.Close()
+	waitForFileTree(t, tree)
+	window.Resize(fyne.NewSize(300, 300))
+	waitForFileTree(t, tree)
+
+	branch, _ := storage.Child(root, "A")
+	leaf, _ := storage.Child(root, "B")
+	leaf, _ = storage.Child(leaf, "C.txt")
+	d := fyne.CurrentApp().Driver()
+	from := d.AbsolutePositionForObject(findFileTreeNode(tree, leaf.String())).Add(fyne.NewPos(5, 5))
+	to := d.AbsolutePositionForObject(findFileTreeNode(tree, branch.String())).Add(fyne.NewPos(5, 5))
+
+	test.Drag(window.Canvas(), from, to.X-from.X, to.Y-from.Y)
+	assert.FileExists(t, path.Join(tempDir, "A", "C.txt"))
+	assert.NoFileExists(t, path.Join(tempDir, "B", "C.txt"))
+}
+
+func findFileTreeNode(tree *FileTree, id widget.TreeNodeID) *fileTreeNode {
+	for _, n := range tree.nodes {
+		if n.id == id && !fyne.CurrentApp().Driver().AbsolutePositionForObject(n).IsZero() {
+			return n
+		}
+	}
+	return nil
+}
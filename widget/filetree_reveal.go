@@ -0,0 +1,144 @@
+package widget
+
+import (
+	"errors"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+var (
+	errRevealOutside  = errors.New("the URI is not inside the tree")
+	errRevealNotShown = errors.New("the URI is not shown in the tree")
+)
+
+// Reveal opens every branch containing a URI, then selects its node and scrolls it into view.
+// Branches that have not been listed yet are listed in the background, and the node is revealed once they have
+// loaded unless another URI is revealed first. An error is returned if the URI is not below a root of the tree.
+// If the URI cannot be found, because it does not exist, it is hidden by the Filter or a branch containing it
+// cannot be listed, the error is returned or, if a branch had to be listed first, passed to OnError.
+func (t *FileTree) Reveal(u fyne.URI) error {
+	parents, err := t.revealParents(u)
+	if err != nil {
+		return err
+	}
+
+	t.cacheLock.Lock()
+	t.revealing = nil
+	t.cacheLock.Unlock()
+	return t.reveal(u, parents)
+}
+
+// continueReveal reveals the URI passed to Reveal once the branches it was waiting for have loaded.
+// It is called as the tree refreshes after a load completes.
+func (t *FileTree) continueReveal() {
+	t.cacheLock.Lock()
+	r := t.revealing
+	t.revealing = nil
+	t.cacheLock.Unlock()
+	if r == nil {
+		return
+	}
+
+	parents, err := t.revealParents(r.uri)
+	if err == nil {
+		err = t.reveal(r.uri, parents)
+	}
+	if err == nil {
+		return
+	}
+	if f := r.onError; f != nil {
+		f(r.uri, err)
+		return
+	}
+	fyne.LogError("Unable to reveal "+r.uri.String(), err)
+}
+
+// reveal opens the parents of a URI and selects it. If a parent has not been listed it starts loading
+// and the reveal continues when it has loaded.
+func (t *FileTree) reveal(u fyne.URI, parents []widget.TreeNodeID) error {
+	id := t.branchID(u)
+	for i, parent := range parents {
+		t.cacheLock.Lock()
+		children, listed := t.childCache[parent]
+		if !listed {
+			t.revealing = &fileTreeReveal{uri: u, onError: t.OnError}
+		}
+		t.cacheLock.Unlock()
+		if !t.IsBranchOpen(parent) { // opening a branch refreshes the whole tree, even if it is already open
+			t.OpenBranch(parent)
+		}
+		if !listed {
+			t.loadChildren(parent)
+			return nil
+		}
+
+		next := id
+		if i+1 < len(parents) {
+			next = parents[i+1]
+		}
+		child, ok := findChild(children, next)
+		if !ok {
+			if err := t.BranchError(parent); err != nil {
+				return err
+			}
+			return errRevealNotShown
+		}
+		if i+1 == len(parents) {
+			id = child
+		} else {
+			parents[i+1] = child
+		}
+	}
+
+	if t.currentCursor() == id {
+		t.Tree.Unselect(id) // widget.Tree only scrolls to a node when its selection changes
+	}
+	t.Select(id)
+	return nil
+}
+
+// revealParents returns the IDs of the branches that must be opened to show a URI, starting at the tree's root.
+func (t *FileTree) revealParents(u fyne.URI) ([]widget.TreeNodeID, error) {
+	var parents []widget.TreeNodeID
+	for {
+		id := t.branchID(u)
+		if t.isRoot(id) {
+			if id != t.Root {
+				parents = append(parents, t.Root) // the root of a workspace contains the other roots
+			}
+			break
+		}
+		parent, err := storage.Parent(u)
+		if err != nil || parent.String() == u.String() {
+			return nil, errRevealOutside
+		}
+		u = parent
+		parents = append(parents, t.branchID(parent))
+	}
+
+	// reverse, so that the outermost branch is opened first
+	for i, j := 0, len(parents)-1; i < j; i, j = i+1, j-1 {
+		parents[i], parents[j] = parents[j], parents[i]
+	}
+	return parents, nil
+}
+
+// findChild returns the ID in a list of children that represents the given ID, ignoring a trailing slash.
+func findChild(children []widget.TreeNodeID, id widget.TreeNodeID) (widget.TreeNodeID, bool) {
+	trimmed := strings.TrimSuffix(id, "/")
+	for _, child := range children {
+		if strings.TrimSuffix(child, "/") == trimmed {
+			return child, true
+		}
+	}
+	return "", false
+}
+
+// fileTreeReveal is a URI passed to Reveal that is waiting for a branch containing it to load.
+type fileTreeReveal struct {
+	uri     fyne.URI
+	onError func(fyne.URI, error)
+}
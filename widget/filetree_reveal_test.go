@@ -0,0 +1,111 @@
+package widget
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestFileTree_Reveal(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+	deep := filepath.Join(tempDir, "B", "E", "F")
+	assert.NoError(t, os.MkdirAll(deep, 0755))
+	for i := 0; i < 30; i++ {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(deep, fmt.Sprintf("%02d.txt", i)), nil, 0644))
+	}
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	window := test.NewWindow(tree)
+	defer window.Close()
+	waitForFileTree(t, tree)
+	window.Resize(fyne.NewSize(300, 200))
+	waitForFileTree(t, tree)
+
+	last := storage.NewFileURI(filepath.Join(deep, "29.txt"))
+	assert.NoError(t, tree.Reveal(last)) // nothing below the root has been listed yet
+	assert.Empty(t, tree.SelectedURIs())
+	waitForFileTree(t, tree)
+	for _, dir := range []string{"B", "B/E", "B/E/F"} {
+		assert.True(t, tree.IsBranchOpen(storage.NewFileURI(filepath.Join(tempDir, dir)).String()), dir)
+	}
+	assert.Equal(t, []fyne.URI{last}, tree.SelectedURIs())
+	assert.Greater(t, tree.State().Offset, float32(0))
+
+	tree.scroller.Offset.Y = 0 // scroll away and reveal the same node again
+	tree.scroller.Refresh()
+	assert.NoError(t, tree.Reveal(last))
+	assert.Greater(t, tree.State().Offset, float32(0))
+
+	assert.NoError(t, tree.Reveal(root))
+	assert.Equal(t, []fyne.URI{root}, tree.SelectedURIs())
+}
+
+func TestFileTree_RevealErrors(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	tree.Filter = storage.NewExtensionFileFilter([]string{".txt"})
+	var failed []fyne.URI
+	var errs []error
+	tree.OnError = func(u fyne.URI, err error) {
+		failed = append(failed, u)
+		errs = append(errs, err)
+	}
+
+	assert.Equal(t, errRevealOutside, tree.Reveal(storage.NewFileURI(filepath.Dir(tempDir))))
+	missing := storage.NewFileURI(filepath.Join(tempDir, "B", "missing.txt"))
+	assert.NoError(t, tree.Reveal(missing)) // reported once the root has been listed
+	waitForFileTree(t, tree)
+	assert.Equal(t, []fyne.URI{missing}, failed)
+	assert.Equal(t, []error{errRevealNotShown}, errs)
+	assert.Equal(t, errRevealNotShown, tree.Reveal(missing)) // the branches are listed now
+	assert.Empty(t, tree.SelectedURIs())
+
+	tree.FilterLeavesOnly = true
+	tree.Reload()
+	waitForFileTree(t, tree)
+	leaf := storage.NewFileURI(filepath.Join(tempDir, "B", "C.txt"))
+	assert.NoError(t, tree.Reveal(leaf))
+	waitForFileTree(t, tree)
+	assert.Equal(t, []fyne.URI{leaf}, tree.SelectedURIs())
+	assert.Equal(t, 1, len(errs))
+}
+
+func TestFileTree_RevealWorkspace(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	rootA := storage.NewFileURI(filepath.Join(tempDir, "A"))
+	rootB := storage.NewFileURI(filepath.Join(tempDir, "B"))
+	tree := NewFileTreeWithRoots(rootA, rootB)
+	defer waitForFileTree(t, tree)
+	tree.SetWorkspaceRoot("Workspace")
+
+	leaf := storage.NewFileURI(filepath.Join(tempDir, "B", "D.txt"))
+	assert.NoError(t, tree.Reveal(leaf))
+	waitForFileTree(t, tree)
+	assert.True(t, tree.IsBranchOpen(workspaceRootID))
+	assert.True(t, tree.IsBranchOpen(rootB.String()))
+	assert.Equal(t, []fyne.URI{leaf}, tree.SelectedURIs())
+	assert.Equal(t, errRevealOutside, tree.Reveal(storage.NewFileURI(tempDir)))
+}
@@ -0,0 +1,290 @@
+package widget
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// typeAheadTimeout is how long after the last key press that typing starts a new search.
+const typeAheadTimeout = time.Second
+
+// fileTreeSearch is a search of the tree's names that lists branches in the background as it goes.
+type fileTreeSearch struct {
+	query   string
+	done    chan struct{}
+	label   func(fyne.URI) string
+	options fileListOptions
+
+	lock    sync.Mutex
+	matches []widget.TreeNodeID
+	current int
+	reveal  widget.TreeNodeID // the first match, until the tree has revealed it
+}
+
+// ClearSearch stops any search and removes the highlighting of matches.
+func (t *FileTree) ClearSearch() {
+	t.searchLock.Lock()
+	s := t.search
+	t.search = nil
+	t.typed = ""
+	t.searchLock.Unlock()
+
+	if s != nil {
+		close(s.done)
+		t.Refresh()
+	}
+}
+
+// NextMatch reveals and selects the match after the currently selected one, wrapping to the first.
+func (t *FileTree) NextMatch() {
+	t.moveMatch(1)
+}
+
+// PreviousMatch reveals and selects the match before the currently selected one, wrapping to the last.
+func (t *FileTree) PreviousMatch() {
+	t.moveMatch(-1)
+}
+
+// Search looks for nodes with a name containing the query, ignoring case. The name is the text shown for
+// each node, so it includes any changes made by Label.
+// Branches are listed in the background, without opening them, and the first match is revealed and selected
+// when the tree next refreshes after it is found. Matched text is highlighted in the names shown.
+// Starting a new search, or passing an empty query, stops any previous search.
+func (t *FileTree) Search(query string) {
+	t.searchLock.Lock()
+	old := t.search
+	t.search = nil
+	if query != "" {
+		t.search = &fileTreeSearch{
+			query:   strings.ToLower(query),
+			done:    make(chan struct{}),
+			label:   t.Label,
+			options: t.listOptions(),
+		}
+	}
+	s := t.search
+	t.searchLock.Unlock()
+
+	if old != nil {
+		close(old.done)
+	}
+	t.Refresh()
+	if s != nil {
+		go t.runSearch(s)
+	}
+}
+
+// SearchMatches returns the URIs matching the current search that have been found so far, in tree order.
+func (t *FileTree) SearchMatches() []fyne.URI {
+	s := t.currentSearch()
+	if s == nil {
+		return nil
+	}
+
+	s.lock.Lock()
+	ids := s.matches
+	s.lock.Unlock()
+	uris := make([]fyne.URI, 0, len(ids))
+	for _, id := range ids {
+		if u, err := t.toURI(id); err == nil {
+			uris = append(uris, u)
+		}
+	}
+	return uris
+}
+
+func (t *FileTree) currentSearch() *fileTreeSearch {
+	t.searchLock.Lock()
+	defer t.searchLock.Unlock()
+	return t.search
+}
+
+// matchRange returns the byte range of the current search query in a name, or -1 if it is not found.
+func (t *FileTree) matchRange(name string) (int, int) {
+	s := t.currentSearch()
+	if s == nil {
+		return -1, -1
+	}
+	start := s.index(name)
+	if start == -1 {
+		return -1, -1
+	}
+	return start, start + len(s.query)
+}
+
+func (t *FileTree) moveMatch(delta int) {
+	s := t.currentSearch()
+	if s == nil {
+		return
+	}
+
+	s.lock.Lock()
+	if len(s.matches) == 0 {
+		s.lock.Unlock()
+		return
+	}
+	s.current = (s.current + delta + len(s.matches)) % len(s.matches)
+	id := s.matches[s.current]
+	s.lock.Unlock()
+	t.revealMatch(id)
+}
+
+// revealFirstMatch reveals the first match of the current search, if it has been found since the last refresh.
+func (t *FileTree) revealFirstMatch() {
+	s := t.currentSearch()
+	if s == nil {
+		return
+	}
+
+	s.lock.Lock()
+	id := s.reveal
+	s.reveal = ""
+	s.lock.Unlock()
+	if id != "" {
+		t.revealMatch(id)
+	}
+}
+
+// revealMatch opens the branches containing a match and selects it. Branches that have not been listed
+// are loaded in the background, as for Reveal.
+func (t *FileTree) revealMatch(id widget.TreeNodeID) {
+	u, err := t.toURI(id)
+	if err == nil {
+		err = t.Reveal(u)
+	}
+	if err != nil {
+		fyne.LogError("Unable to reveal search match "+id, err)
+	}
+}
+
+// runSearch walks the tree in display order, recording each match, until it is finished or stopped.
+func (t *FileTree) runSearch(s *fileTreeSearch) {
+	var walk func(id widget.TreeNodeID) bool
+	walk = func(id widget.TreeNodeID) bool {
+		select {
+		case <-s.done:
+			return false
+		default:
+		}
+		if !t.IsBranch(id) {
+			return true
+		}
+
+		for _, child := range t.searchChildren(s, id) {
+			if u, err := t.toURI(child); err == nil && s.index(t.labelWith(s.label, child, u)) != -1 {
+				t.searchMatched(s, child)
+			}
+			if !walk(child) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if !walk(t.Root) {
+		return
+	}
+	if f := t.OnSearchChanged; f != nil {
+		f(t.SearchMatches(), true)
+	}
+}
+
+// searchChildren returns the children of a branch, from the tree if it has listed them
+// or listing them just for the search. Listings made for the search are not kept, so a search of a large
+// folder does not hold on to all of it; the branches containing a match are listed again to reveal it.
+// Links that would list a folder inside itself are skipped, so the walk always ends.
+func (t *FileTree) searchChildren(s *fileTreeSearch, id widget.TreeNodeID) []widget.TreeNodeID {
+	t.cacheLock.Lock()
+	children, ok := t.childCache[id]
+	t.cacheLock.Unlock()
+	if ok {
+		return children
+	}
+
+	listable, err := t.toListable(id)
+	if err != nil || isSymlinkLoop(listable) {
+		return nil
+	}
+	uris, err := listable.List()
+	if err != nil {
+		return nil
+	}
+	for _, u := range s.options.sort(s.options.filter(uris, t.IsBranch)) {
+		children = append(children, u.String())
+	}
+	return children
+}
+
+// searchMatched records a match found in the background. The first match is revealed by the next refresh,
+// rather than from the search, as revealing it changes the tree.
+func (t *FileTree) searchMatched(s *fileTreeSearch, id widget.TreeNodeID) {
+	s.lock.Lock()
+	s.matches = append(s.matches, id)
+	first := len(s.matches) == 1
+	if first {
+		s.reveal = id
+	}
+	s.lock.Unlock()
+
+	if first {
+		t.refreshLoaded()
+	}
+	if f := t.OnSearchChanged; f != nil {
+		f(t.SearchMatches(), false)
+	}
+}
+
+// typeAhead adds a typed character to the search query, starting a new query if typing had paused.
+func (t *FileTree) typeAhead(r rune) {
+	t.searchLock.Lock()
+	if time.Since(t.typedAt) > typeAheadTimeout {
+		t.typed = ""
+	}
+	if r == ' ' && t.typed == "" { // a space on its own is used to toggle the selection
+		t.searchLock.Unlock()
+		return
+	}
+	t.typed += string(r)
+	t.typedAt = time.Now()
+	query := t.typed
+	t.searchLock.Unlock()
+
+	t.Search(query)
+}
+
+// typeAheadBackspace removes the last typed character from the search query.
+func (t *FileTree) typeAheadBackspace() {
+	t.searchLock.Lock()
+	if t.typed == "" {
+		t.searchLock.Unlock()
+		return
+	}
+	runes := []rune(t.typed)
+	t.typed = string(runes[:len(runes)-1])
+	t.typedAt = time.Now()
+	query := t.typed
+	t.searchLock.Unlock()
+
+	t.Search(query)
+}
+
+// typingAhead returns true if a search query is being typed, so a space is part of the query.
+func (t *FileTree) typingAhead() bool {
+	t.searchLock.Lock()
+	defer t.searchLock.Unlock()
+	return t.typed != "" && time.Since(t.typedAt) <= typeAheadTimeout
+}
+
+// index returns the byte offset of the query in a name, ignoring case, or -1 if it is not found.
+// Names that change length when lower cased are not matched, as the offset would not line up with the name.
+func (s *fileTreeSearch) index(name string) int {
+	lower := strings.ToLower(name)
+	if len(lower) != len(name) {
+		return -1
+	}
+	return strings.Index(lower, s.query)
+}
@@ -0,0 +1,171 @@
+package widget
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+)
+
+func TestFileTree_Search(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	window := test.NewWindow(tree)
+	defer window.Close()
+	waitForFileTree(t, tree)
+	window.Resize(fyne.NewSize(300, 300))
+	waitForFileTree(t, tree)
+
+	finished := make(chan []fyne.URI, 1)
+	tree.OnSearchChanged = func(matches []fyne.URI, done bool) {
+		if done {
+			finished <- matches
+		}
+	}
+	branchA, _ := storage.Child(root, "A")
+	branchB, _ := storage.Child(root, "B")
+	leafC, _ := storage.Child(branchB, "C.txt")
+	leafD, _ := storage.Child(branchB, "D.txt")
+
+	tree.Search("TXT")
+	select {
+	case matches := <-finished:
+		assert.Equal(t, []fyne.URI{leafC, leafD}, matches)
+	case <-time.After(time.Second):
+		t.Fatal("search did not finish")
+	}
+	waitForFileTree(t, tree) // the first match is revealed by a refresh
+	assert.True(t, tree.IsBranchOpen(branchB.String()))
+	assert.False(t, tree.IsBranchOpen(branchA.String()))
+	assert.Equal(t, []fyne.URI{leafC}, tree.SelectedURIs())
+
+	node := findFileTreeNode(tree, leafD.String())
+	assert.True(t, node.match.Visible())
+	assert.Equal(t, fyne.MeasureText("txt", theme.TextSize(), fyne.TextStyle{}), node.match.Size())
+
+	tree.NextMatch()
+	assert.Equal(t, []fyne.URI{leafD}, tree.SelectedURIs())
+	tree.NextMatch()
+	assert.Equal(t, []fyne.URI{leafC}, tree.SelectedURIs())
+	tree.PreviousMatch()
+	assert.Equal(t, []fyne.URI{leafD}, tree.SelectedURIs())
+
+	tree.ClearSearch()
+	assert.Nil(t, tree.SearchMatches())
+	assert.False(t, node.match.Visible())
+
+	tree.Label = func(u fyne.URI) string {
+		if u.String() == leafD.String() {
+			return "Delta"
+		}
+		return ""
+	}
+	tree.Search("elt")
+	select {
+	case matches := <-finished:
+		assert.Equal(t, []fyne.URI{leafD}, matches)
+	case <-time.After(time.Second):
+		t.Fatal("search did not finish")
+	}
+	waitForFileTree(t, tree)
+	assert.Equal(t, []fyne.URI{leafD}, tree.SelectedURIs())
+	node = findFileTreeNode(tree, leafD.String())
+	assert.True(t, node.match.Visible())
+	assert.Equal(t, fyne.MeasureText("elt", theme.TextSize(), fyne.TextStyle{}), node.match.Size())
+}
+
+func TestFileTree_SearchTyped(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	window := test.NewWindow(tree)
+	defer window.Close()
+	waitForFileTree(t, tree)
+	window.Resize(fyne.NewSize(300, 300))
+	waitForFileTree(t, tree)
+
+	branchB, _ := storage.Child(root, "B")
+	leafC, _ := storage.Child(branchB, "C.txt")
+	leafD, _ := storage.Child(branchB, "D.txt")
+
+	window.Canvas().Focus(tree)
+	test.Type(tree, "d.")
+	assert.Eventually(t, func() bool {
+		matches := tree.SearchMatches()
+		return len(matches) == 1 && matches[0].String() == leafD.String()
+	}, time.Second, 10*time.Millisecond)
+	waitForFileTree(t, tree)
+
+	tree.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+	tree.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+	test.Type(tree, "c")
+	assert.Eventually(t, func() bool {
+		matches := tree.SearchMatches()
+		return len(matches) == 1 && matches[0].String() == leafC.String()
+	}, time.Second, 10*time.Millisecond)
+	waitForFileTree(t, tree)
+
+	tree.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEscape})
+	assert.Nil(t, tree.SearchMatches())
+}
+
+func TestFileTree_SearchDeep(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	dir := root
+	for i := 0; i < 34; i++ { // deeper than searches used to go
+		dir, _ = storage.Child(dir, "E")
+	}
+	assert.NoError(t, os.MkdirAll(dir.Path(), 0755))
+	leaf, _ := storage.Child(dir, "deep.txt")
+	assert.NoError(t, ioutil.WriteFile(leaf.Path(), nil, 0644))
+
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	window := test.NewWindow(tree)
+	defer window.Close()
+	window.Resize(fyne.NewSize(300, 300))
+	waitForFileTree(t, tree)
+
+	finished := make(chan []fyne.URI, 1)
+	tree.OnSearchChanged = func(matches []fyne.URI, done bool) {
+		if done {
+			finished <- matches
+		}
+	}
+	tree.Search("deep")
+	select {
+	case matches := <-finished:
+		assert.Equal(t, []fyne.URI{leaf}, matches)
+	case <-time.After(time.Second):
+		t.Fatal("search did not finish")
+	}
+	assert.Eventually(t, func() bool { // each branch containing the match is listed in the background in turn
+		return len(tree.SelectedURIs()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	waitForFileTree(t, tree)
+	assert.True(t, tree.IsBranchOpen(dir.String()))
+	assert.Equal(t, []fyne.URI{leaf}, tree.SelectedURIs())
+}
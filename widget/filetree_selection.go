@@ -0,0 +1,373 @@
+package widget
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+var _ fyne.Focusable = (*FileTree)(nil)
+var _ fyne.Shortcutable = (*FileTree)(nil)
+var _ desktop.Keyable = (*FileTree)(nil)
+
+// Select marks the specified node as the only selected node.
+func (t *FileTree) Select(uid widget.TreeNodeID) {
+	t.setSelection([]widget.TreeNodeID{uid}, uid, uid)
+}
+
+// SelectAll selects every node that is currently visible in the tree.
+func (t *FileTree) SelectAll() {
+	ids := t.visibleNodes()
+	if len(ids) == 0 {
+		return
+	}
+	t.setSelection(ids, t.currentAnchor(), t.currentCursor())
+}
+
+// SelectRange selects the visible nodes between from and to, including both.
+// Further range selections with the keyboard or Shift-click will extend from the from node.
+func (t *FileTree) SelectRange(from, to widget.TreeNodeID) {
+	ids := t.visibleNodes()
+	start, end := indexOfNode(ids, from), indexOfNode(ids, to)
+	if start == -1 {
+		t.Select(to)
+		return
+	}
+	if end == -1 {
+		return
+	}
+	if start > end {
+		start, end = end, start
+	}
+
+	t.setSelection(append([]widget.TreeNodeID{}, ids[start:end+1]...), from, to)
+}
+
+// SelectedURIs returns the URIs of all selected nodes, in the order they were selected.
+func (t *FileTree) SelectedURIs() []fyne.URI {
+	t.selectionLock.RLock()
+	ids := t.selection
+	t.selectionLock.RUnlock()
+
+	var uris []fyne.URI
+	for _, id := range ids {
+		if u, err := t.toURI(id); err == nil {
+			uris = append(uris, u)
+		}
+	}
+	return uris
+}
+
+// SetSelectedURIs replaces the selection with the nodes representing the given URIs.
+func (t *FileTree) SetSelectedURIs(uris []fyne.URI) {
+	ids := make([]widget.TreeNodeID, len(uris))
+	for i, u := range uris {
+		ids[i] = t.branchID(u)
+	}
+	var last widget.TreeNodeID
+	if len(ids) > 0 {
+		last = ids[len(ids)-1]
+	}
+	t.setSelection(ids, last, last)
+}
+
+// ToggleSelected adds the node to the selection, or removes it if it was already selected.
+func (t *FileTree) ToggleSelected(uid widget.TreeNodeID) {
+	t.selectionLock.RLock()
+	ids := t.selection
+	t.selectionLock.RUnlock()
+
+	if i := indexOfNode(ids, uid); i != -1 {
+		t.setSelection(append(append([]widget.TreeNodeID{}, ids[:i]...), ids[i+1:]...), uid, uid)
+		return
+	}
+	t.setSelection(append(append([]widget.TreeNodeID{}, ids...), uid), uid, uid)
+}
+
+// Unselect removes the specified node from the selection.
+func (t *FileTree) Unselect(uid widget.TreeNodeID) {
+	t.selectionLock.RLock()
+	ids := t.selection
+	t.selectionLock.RUnlock()
+
+	if i := indexOfNode(ids, uid); i != -1 {
+		t.setSelection(append(append([]widget.TreeNodeID{}, ids[:i]...), ids[i+1:]...), t.currentAnchor(), t.currentCursor())
+	}
+}
+
+// UnselectAll clears the selection.
+func (t *FileTree) UnselectAll() {
+	t.setSelection(nil, "", t.currentCursor())
+}
+
+// FocusGained is called when the tree has been given focus.
+//
+// Implements: fyne.Focusable
+func (t *FileTree) FocusGained() {
+}
+
+// FocusLost is called when the tree has had focus removed.
+//
+// Implements: fyne.Focusable
+func (t *FileTree) FocusLost() {
+	t.shift = false
+}
+
+// KeyDown tracks the Shift key so that moving with the arrow keys extends the selection.
+//
+// Implements: desktop.Keyable
+func (t *FileTree) KeyDown(ev *fyne.KeyEvent) {
+	if ev.Name == desktop.KeyShiftLeft || ev.Name == desktop.KeyShiftRight {
+		t.shift = true
+	}
+}
+
+// KeyUp tracks the Shift key so that moving with the arrow keys extends the selection.
+//
+// Implements: desktop.Keyable
+func (t *FileTree) KeyUp(ev *fyne.KeyEvent) {
+	if ev.Name == desktop.KeyShiftLeft || ev.Name == desktop.KeyShiftRight {
+		t.shift = false
+	}
+}
+
+// TypedKey moves the selection with the arrow keys, extending it if Shift is held.
+// Space toggles the node at the cursor and left or right close and open branches.
+// While searching F3 moves to the next match (or previous with Shift), Backspace changes the query
+// and Escape ends the search.
+//
+// Implements: fyne.Focusable
+func (t *FileTree) TypedKey(ev *fyne.KeyEvent) {
+	switch ev.Name {
+	case fyne.KeyEscape:
+		t.ClearSearch()
+	case fyne.KeyBackspace:
+		t.typeAheadBackspace()
+	case fyne.KeyF3:
+		if t.shift {
+			t.PreviousMatch()
+		} else {
+			t.NextMatch()
+		}
+	case fyne.KeyUp, fyne.KeyDown, fyne.KeyHome, fyne.KeyEnd:
+		next := t.cursorMoved(ev.Name)
+		if next == "" {
+			return
+		}
+		if t.shift {
+			t.SelectRange(t.currentAnchor(), next)
+		} else {
+			t.Select(next)
+		}
+	case fyne.KeySpace:
+		if cursor := t.currentCursor(); cursor != "" && !t.typingAhead() {
+			t.ToggleSelected(cursor)
+		}
+	case fyne.KeyLeft:
+		if cursor := t.currentCursor(); cursor != "" && t.IsBranchOpen(cursor) {
+			t.CloseBranch(cursor)
+		}
+	case fyne.KeyRight:
+		if cursor := t.currentCursor(); cursor != "" && t.IsBranch(cursor) {
+			t.OpenBranch(cursor)
+		}
+	}
+}
+
+// TypedRune searches for the text typed while the tree has focus, see Search.
+//
+// Implements: fyne.Focusable
+func (t *FileTree) TypedRune(r rune) {
+	t.typeAhead(r)
+}
+
+// TypedShortcut selects all visible nodes for the select all shortcut.
+// With Control (or Command) held the arrow keys move the cursor without changing the selection,
+// and Space toggles the node at the cursor.
+//
+// Implements: fyne.Shortcutable
+func (t *FileTree) TypedShortcut(s fyne.Shortcut) {
+	switch sh := s.(type) {
+	case *fyne.ShortcutSelectAll:
+		t.SelectAll()
+	case *desktop.CustomShortcut:
+		if sh.Modifier&(desktop.ControlModifier|desktop.SuperModifier) == 0 {
+			return
+		}
+		switch sh.KeyName {
+		case fyne.KeyUp, fyne.KeyDown, fyne.KeyHome, fyne.KeyEnd:
+			if next := t.cursorMoved(sh.KeyName); next != "" {
+				t.selectionLock.Lock()
+				t.cursor = next
+				t.selectionLock.Unlock()
+			}
+		case fyne.KeySpace:
+			if cursor := t.currentCursor(); cursor != "" {
+				t.ToggleSelected(cursor)
+			}
+		}
+	}
+}
+
+func (t *FileTree) currentAnchor() widget.TreeNodeID {
+	t.selectionLock.RLock()
+	defer t.selectionLock.RUnlock()
+	return t.anchor
+}
+
+func (t *FileTree) currentCursor() widget.TreeNodeID {
+	t.selectionLock.RLock()
+	defer t.selectionLock.RUnlock()
+	return t.cursor
+}
+
+// cursorMoved returns the visible node that the cursor moves to when the given key is pressed.
+func (t *FileTree) cursorMoved(key fyne.KeyName) widget.TreeNodeID {
+	ids := t.visibleNodes()
+	if len(ids) == 0 {
+		return ""
+	}
+
+	i := indexOfNode(ids, t.currentCursor())
+	switch key {
+	case fyne.KeyHome:
+		i = 0
+	case fyne.KeyEnd:
+		i = len(ids) - 1
+	case fyne.KeyUp:
+		if i > 0 {
+			i--
+		} else {
+			i = 0
+		}
+	case fyne.KeyDown:
+		if i < len(ids)-1 {
+			i++
+		}
+	}
+	return ids[i]
+}
+
+// isMultiSelected returns true if the node is selected along with others.
+// A single selection is shown by the tree itself, so only multiple selections are highlighted by the nodes.
+func (t *FileTree) isMultiSelected(id widget.TreeNodeID) bool {
+	t.selectionLock.RLock()
+	defer t.selectionLock.RUnlock()
+	return len(t.selection) > 1 && indexOfNode(t.selection, id) != -1
+}
+
+// selectedOrNode returns the selected nodes if the node is one of them, or just the node otherwise.
+// Nodes inside another selected folder are left out, as they go wherever their folder does.
+func (t *FileTree) selectedOrNode(id widget.TreeNodeID) []widget.TreeNodeID {
+	t.selectionLock.RLock()
+	ids := t.selection
+	t.selectionLock.RUnlock()
+	if indexOfNode(ids, id) == -1 {
+		return []widget.TreeNodeID{id}
+	}
+
+	var top []widget.TreeNodeID
+	for _, id := range ids {
+		inside := false
+		for _, other := range ids {
+			if other != id && strings.HasPrefix(id, strings.TrimSuffix(other, "/")+"/") {
+				inside = true
+				break
+			}
+		}
+		if !inside {
+			top = append(top, id)
+		}
+	}
+	return top
+}
+
+// setSelection replaces the selected nodes, keeping the tree's own selection on the cursor.
+func (t *FileTree) setSelection(ids []widget.TreeNodeID, anchor, cursor widget.TreeNodeID) {
+	t.selectionLock.Lock()
+	t.selection, t.anchor, t.cursor = ids, anchor, cursor
+	t.selectionLock.Unlock()
+
+	if indexOfNode(ids, cursor) != -1 {
+		t.Tree.Select(cursor)
+	} else if len(ids) > 0 {
+		t.Tree.Select(ids[len(ids)-1])
+	} else {
+		t.Tree.Unselect(cursor)
+	}
+	t.Refresh()
+	t.stateChanged()
+
+	if f := t.OnSelectionChanged; f != nil {
+		f(t.SelectedURIs())
+	}
+}
+
+// treeSelected is called when widget.Tree selects a node. Nodes selected by the FileTree are already part of
+// its selection, others, such as by tapping the row outside the node, replace the selection as Select does.
+func (t *FileTree) treeSelected(uid widget.TreeNodeID) {
+	t.selectionLock.RLock()
+	selected := indexOfNode(t.selection, uid) != -1
+	t.selectionLock.RUnlock()
+	if !selected {
+		t.Select(uid)
+	}
+
+	if f := t.OnSelected; f != nil {
+		f(uid)
+	}
+}
+
+// unselectRemoved removes a URI that no longer exists, and anything inside it, from the selection.
+func (t *FileTree) unselectRemoved(u fyne.URI) {
+	id := t.branchID(u)
+	prefix := strings.TrimSuffix(id, "/") + "/"
+
+	t.selectionLock.RLock()
+	ids := t.selection
+	t.selectionLock.RUnlock()
+
+	var kept []widget.TreeNodeID
+	for _, s := range ids {
+		if s != id && !strings.HasPrefix(s, prefix) {
+			kept = append(kept, s)
+		}
+	}
+	if len(kept) != len(ids) {
+		t.setSelection(kept, t.currentAnchor(), t.currentCursor())
+	}
+}
+
+// visibleNodes returns the IDs of every node that is shown when scrolling through the tree, in order.
+// Branches that are still loading are treated as empty.
+func (t *FileTree) visibleNodes() (ids []widget.TreeNodeID) {
+	var walk func(id widget.TreeNodeID)
+	walk = func(id widget.TreeNodeID) {
+		if id != "" && !isErrorNode(id) { // an empty root is not shown, and error nodes cannot be selected
+			ids = append(ids, id)
+		}
+		if !t.IsBranchOpen(id) {
+			return
+		}
+
+		t.cacheLock.Lock()
+		children := t.childCache[id]
+		t.cacheLock.Unlock()
+		for _, child := range children {
+			walk(child)
+		}
+	}
+	walk(t.Root)
+	return
+}
+
+func indexOfNode(ids []widget.TreeNodeID, id widget.TreeNodeID) int {
+	for i, s := range ids {
+		if s == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,139 @@
+package widget
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestFileTree_Selection(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	tree.OpenAllBranches()
+	window := test.NewWindow(tree)
+	defer window.Close()
+	waitForFileTree(t, tree)
+	window.Resize(fyne.NewSize(300, 300))
+	waitForFileTree(t, tree)
+
+	var changed []fyne.URI
+	tree.OnSelectionChanged = func(uris []fyne.URI) {
+		changed = uris
+	}
+	branchA, _ := storage.Child(root, "A")
+	branchB, _ := storage.Child(root, "B")
+	leafC, _ := storage.Child(branchB, "C.txt")
+	leafD, _ := storage.Child(branchB, "D.txt")
+
+	test.Tap(findFileTreeNode(tree, branchA.String()))
+	assert.Equal(t, []fyne.URI{branchA}, changed)
+	assert.Equal(t, tree, window.Canvas().Focused())
+
+	node := findFileTreeNode(tree, leafC.String())
+	node.MouseDown(&desktop.MouseEvent{Modifier: desktop.ShiftModifier})
+	test.Tap(node)
+	assert.Equal(t, []fyne.URI{branchA, branchB, leafC}, tree.SelectedURIs())
+	assert.True(t, findFileTreeNode(tree, branchB.String()).background.Visible())
+
+	node = findFileTreeNode(tree, branchB.String())
+	node.MouseDown(&desktop.MouseEvent{Modifier: desktop.ControlModifier})
+	test.Tap(node)
+	assert.Equal(t, []fyne.URI{branchA, leafC}, changed)
+	assert.False(t, node.background.Visible())
+
+	tree.ToggleSelected(leafC.String())
+	assert.Equal(t, []fyne.URI{branchA}, tree.SelectedURIs())
+	tree.UnselectAll()
+	assert.Empty(t, tree.SelectedURIs())
+
+	tree.SetSelectedURIs([]fyne.URI{leafD})
+	assert.Equal(t, []fyne.URI{leafD}, changed)
+	tree.EnableOperations().Delete(branchB)
+	assert.Empty(t, tree.SelectedURIs())
+}
+
+func TestFileTree_SelectionRow(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	tree.OpenAllBranches()
+	var changed []fyne.URI
+	tree.OnSelectionChanged = func(uris []fyne.URI) {
+		changed = uris
+	}
+	var selected []string
+	tree.OnSelected = func(uid string) {
+		selected = append(selected, uid)
+	}
+	branchA, _ := storage.Child(root, "A")
+	branchB, _ := storage.Child(root, "B")
+	leafC, _ := storage.Child(branchB, "C.txt")
+
+	tree.SelectRange(branchA.String(), branchB.String())
+	assert.Equal(t, []string{branchB.String()}, selected)
+
+	tree.Tree.Select(leafC.String()) // as tapping the row outside the node does
+	assert.Equal(t, []fyne.URI{leafC}, changed)
+	assert.Equal(t, []fyne.URI{leafC}, tree.SelectedURIs())
+	assert.Equal(t, leafC.String(), tree.currentAnchor())
+	assert.Equal(t, []string{branchB.String(), leafC.String()}, selected)
+}
+
+func TestFileTree_SelectionKeyboard(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	tree.OpenAllBranches()
+	window := test.NewWindow(tree)
+	defer window.Close()
+	waitForFileTree(t, tree)
+	window.Resize(fyne.NewSize(300, 300))
+	waitForFileTree(t, tree)
+
+	branchA, _ := storage.Child(root, "A")
+	branchB, _ := storage.Child(root, "B")
+	leafC, _ := storage.Child(branchB, "C.txt")
+	leafD, _ := storage.Child(branchB, "D.txt")
+
+	tree.Select(branchA.String())
+	tree.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	assert.Equal(t, []fyne.URI{branchB}, tree.SelectedURIs())
+
+	tree.KeyDown(&fyne.KeyEvent{Name: desktop.KeyShiftLeft})
+	tree.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	tree.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	tree.KeyUp(&fyne.KeyEvent{Name: desktop.KeyShiftLeft})
+	assert.Equal(t, []fyne.URI{branchB, leafC, leafD}, tree.SelectedURIs())
+
+	tree.TypedShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyUp, Modifier: desktop.ControlModifier})
+	tree.TypedShortcut(&desktop.CustomShortcut{KeyName: fyne.KeySpace, Modifier: desktop.ControlModifier})
+	assert.Equal(t, []fyne.URI{branchB, leafD}, tree.SelectedURIs())
+
+	tree.TypedShortcut(&fyne.ShortcutSelectAll{})
+	assert.Equal(t, 5, len(tree.SelectedURIs()))
+
+	tree.TypedKey(&fyne.KeyEvent{Name: fyne.KeyHome})
+	assert.Equal(t, []fyne.URI{root}, tree.SelectedURIs())
+}
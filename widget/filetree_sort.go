@@ -0,0 +1,176 @@
+package widget
+
+import (
+	"path"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// SortByName orders URIs by name, ignoring case and comparing numbers by value so that "file2" is before "file10".
+func SortByName(a, b fyne.URI) bool {
+	return naturalLess(a.Name(), b.Name())
+}
+
+// SortByExtension orders URIs by the extension of their name, then by name.
+// Names without an extension are first.
+func SortByExtension(a, b fyne.URI) bool {
+	extA, extB := strings.ToLower(path.Ext(a.Name())), strings.ToLower(path.Ext(b.Name()))
+	if extA != extB {
+		return extA < extB
+	}
+	return SortByName(a, b)
+}
+
+// SortByModified orders URIs from the least to the most recently modified, then by name.
+// The times come from the FileMetadataProvider for each URI scheme, URIs without a modification time are last.
+func SortByModified(a, b fyne.URI) bool {
+	var timeA, timeB time.Time
+	if m := fileMetadata(a); m != nil {
+		timeA = m.Modified
+	}
+	if m := fileMetadata(b); m != nil {
+		timeB = m.Modified
+	}
+	if timeA.IsZero() || timeB.IsZero() {
+		if timeA.IsZero() != timeB.IsZero() {
+			return timeB.IsZero()
+		}
+		return SortByName(a, b)
+	}
+	if !timeA.Equal(timeB) {
+		return timeA.Before(timeB)
+	}
+	return SortByName(a, b)
+}
+
+// SortBySize orders URIs from the smallest to the largest, then by name.
+// The sizes come from the FileMetadataProvider for each URI scheme, URIs without a size are last.
+func SortBySize(a, b fyne.URI) bool {
+	sizeA, sizeB := int64(-1), int64(-1)
+	if m := fileMetadata(a); m != nil {
+		sizeA = m.Size
+	}
+	if m := fileMetadata(b); m != nil {
+		sizeB = m.Size
+	}
+	if sizeA < 0 || sizeB < 0 {
+		if (sizeA < 0) != (sizeB < 0) {
+			return sizeB < 0
+		}
+		return SortByName(a, b)
+	}
+	if sizeA != sizeB {
+		return sizeA < sizeB
+	}
+	return SortByName(a, b)
+}
+
+// SortDescending returns a sorter that reverses the order of the given sorter.
+func SortDescending(sorter func(fyne.URI, fyne.URI) bool) func(fyne.URI, fyne.URI) bool {
+	return func(a, b fyne.URI) bool {
+		return sorter(b, a)
+	}
+}
+
+// SortDirectoriesFirst returns a sorter that puts folders before files, ordering each using the given sorter.
+func SortDirectoriesFirst(sorter func(fyne.URI, fyne.URI) bool) func(fyne.URI, fyne.URI) bool {
+	return func(a, b fyne.URI) bool {
+		dirA, dirB := isDirectory(a), isDirectory(b)
+		if dirA != dirB {
+			return dirA
+		}
+		return sorter(a, b)
+	}
+}
+
+// SetSorter changes the order of nodes and sorts all branches that have been listed, without listing them again.
+func (t *FileTree) SetSorter(sorter func(fyne.URI, fyne.URI) bool) {
+	t.Sorter = sorter
+
+	t.cacheLock.Lock()
+	branches := make(map[widget.TreeNodeID][]widget.TreeNodeID, len(t.childCache))
+	for id, children := range t.childCache {
+		if id != t.Root || t.rootNames == nil { // the roots of a workspace stay in the order they were added
+			branches[id] = children
+		}
+	}
+	t.cacheLock.Unlock()
+
+	for id, children := range branches {
+		uris := make([]fyne.URI, 0, len(children))
+		for _, child := range children {
+			if u, err := t.toURI(child); err == nil {
+				uris = append(uris, u)
+			}
+		}
+
+		sorted := make([]widget.TreeNodeID, len(uris))
+		for i, u := range t.sort(uris) {
+			sorted[i] = u.String()
+		}
+		t.cacheLock.Lock()
+		if _, ok := t.childCache[id]; ok { // it may have been invalidated while sorting
+			t.childCache[id] = sorted
+		}
+		t.cacheLock.Unlock()
+	}
+	t.Refresh()
+}
+
+func isDirectory(u fyne.URI) bool {
+	if m := fileMetadata(u); m != nil {
+		return m.Directory
+	}
+	listable, _ := storage.CanList(u)
+	return listable
+}
+
+// naturalLess compares names ignoring case, treating runs of digits as numbers.
+// Names that only differ by case or leading zeros are ordered by their text so the order is always the same.
+func naturalLess(a, b string) bool {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		ra, sizeA := utf8.DecodeRuneInString(a[i:])
+		rb, sizeB := utf8.DecodeRuneInString(b[j:])
+		if isDigit(ra) && isDigit(rb) {
+			endA, endB := digitsEnd(a, i), digitsEnd(b, j)
+			numA, numB := strings.TrimLeft(a[i:endA], "0"), strings.TrimLeft(b[j:endB], "0")
+			if len(numA) != len(numB) {
+				return len(numA) < len(numB)
+			}
+			if numA != numB {
+				return numA < numB
+			}
+			i, j = endA, endB
+			continue
+		}
+
+		la, lb := unicode.ToLower(ra), unicode.ToLower(rb)
+		if la != lb {
+			return la < lb
+		}
+		i += sizeA
+		j += sizeB
+	}
+	if len(a)-i != len(b)-j {
+		return len(a)-i < len(b)-j
+	}
+	return a < b
+}
+
+func digitsEnd(s string, i int) int {
+	for i < len(s) && isDigit(rune(s[i])) {
+		i++
+	}
+	return i
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
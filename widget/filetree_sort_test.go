@@ -0,0 +1,77 @@
+package widget
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+)
+
+func TestNaturalLess(t *testing.T) {
+	names := []string{"file10", "File2", "file1", "a", "file02", "file2", "B", "file1b", "file1a"}
+	sort.Slice(names, func(i, j int) bool {
+		return naturalLess(names[i], names[j])
+	})
+	assert.Equal(t, []string{"a", "B", "file1", "file1a", "file1b", "File2", "file02", "file2", "file10"}, names)
+}
+
+func TestFileTree_Sorters(t *testing.T) {
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+	writeFile(t, tempDir, "file10.txt", "10")
+	writeFile(t, tempDir, "file2.go", "2")
+	writeFile(t, tempDir, "file1.txt", "one")
+	old := time.Now().Add(-time.Hour)
+	assert.NoError(t, os.Chtimes(filepath.Join(tempDir, "file10.txt"), old, old))
+
+	root := storage.NewFileURI(tempDir)
+	names := func(sorter func(fyne.URI, fyne.URI) bool) (names []string) {
+		uris, err := storage.List(root)
+		assert.NoError(t, err)
+		sort.Slice(uris, func(i, j int) bool {
+			return sorter(uris[i], uris[j])
+		})
+		for _, u := range uris {
+			names = append(names, u.Name())
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"A", "B", "file1.txt", "file2.go", "file10.txt"}, names(SortByName))
+	assert.Equal(t, []string{"A", "B", "file2.go", "file1.txt", "file10.txt"}, names(SortByExtension))
+	assert.Equal(t, []string{"B", "A", "file10.txt", "file2.go", "file1.txt"}, names(SortDirectoriesFirst(SortDescending(SortByName))))
+	assert.Equal(t, []string{"file2.go", "file10.txt", "file1.txt"}, names(SortBySize)[:3])
+	assert.Equal(t, "file10.txt", names(SortByModified)[0])
+}
+
+func TestFileTree_SetSorter(t *testing.T) {
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+	writeFile(t, tempDir, "B/E.txt", "e")
+	writeFile(t, tempDir, "B/10.txt", "10")
+	writeFile(t, tempDir, "B/9.txt", "9")
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	tree.OpenAllBranches()
+	branch, _ := storage.Child(root, "B")
+	names := func() (names []string) {
+		for _, id := range tree.ChildUIDs(branch.String()) {
+			u, _ := storage.ParseURI(id)
+			names = append(names, u.Name())
+		}
+		return names
+	}
+
+	tree.SetSorter(SortByName)
+	assert.Equal(t, []string{"9.txt", "10.txt", "C.txt", "D.txt", "E.txt"}, names())
+	tree.SetSorter(SortDescending(SortByName))
+	assert.Equal(t, []string{"E.txt", "D.txt", "C.txt", "10.txt", "9.txt"}, names())
+}
@@ -0,0 +1,228 @@
+package widget
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// persistStateDelay is how long the tree waits after a change before saving its state,
+// so that a burst of changes such as scrolling is only saved once.
+const persistStateDelay = 500 * time.Millisecond
+
+// FileTreeState is a snapshot of the open branches, selection and scroll position of a FileTree.
+// It can be encoded as JSON to save it between runs of an application.
+type FileTreeState struct {
+	// Open lists the IDs of the open branches, parents before their children.
+	Open []widget.TreeNodeID `json:"open,omitempty"`
+	// Selected lists the IDs of the selected nodes, in the order they were selected.
+	Selected []widget.TreeNodeID `json:"selected,omitempty"`
+	// Offset is the vertical scroll position.
+	Offset float32 `json:"offset,omitempty"`
+}
+
+type fileTreePersistence struct {
+	preferences fyne.Preferences
+	key         string
+	saved       string
+	timer       *time.Timer
+}
+
+// CreateRenderer is a private method to Fyne which links this widget to its renderer.
+func (t *FileTree) CreateRenderer() fyne.WidgetRenderer {
+	r := t.Tree.CreateRenderer()
+	for _, o := range r.Objects() {
+		if s, ok := o.(*container.Scroll); ok {
+			scrolled := s.OnScrolled
+			s.OnScrolled = func(pos fyne.Position) {
+				if scrolled != nil {
+					scrolled(pos)
+				}
+				t.stateChanged()
+			}
+			t.stateLock.Lock()
+			t.scroller = s
+			t.stateLock.Unlock()
+		}
+	}
+	return &fileTreeRenderer{WidgetRenderer: r, tree: t}
+}
+
+// PersistState restores the state saved under the key of the preferences, then saves the state there
+// shortly after each change to the open branches, selection or scroll position.
+// Passing nil preferences stops saving.
+func (t *FileTree) PersistState(preferences fyne.Preferences, key string) {
+	t.stateLock.Lock()
+	if old := t.persistence; old != nil && old.timer != nil {
+		old.timer.Stop()
+	}
+	t.persistence = nil
+	t.stateLock.Unlock()
+	if preferences == nil {
+		return
+	}
+
+	saved := preferences.String(key)
+	if saved != "" {
+		state := &FileTreeState{}
+		if err := json.Unmarshal([]byte(saved), state); err != nil {
+			fyne.LogError("Unable to read the state of the file tree", err)
+		} else {
+			t.RestoreState(state)
+		}
+	}
+
+	t.stateLock.Lock()
+	t.persistence = &fileTreePersistence{preferences: preferences, key: key, saved: saved}
+	t.stateLock.Unlock()
+}
+
+// RestoreState opens the branches and selects the nodes of a state returned by State, then scrolls to its offset.
+// Branches and nodes that no longer exist are skipped. Branches that have not been listed yet are listed in
+// the background, the scroll position is applied again as they finish so that it can reach their content.
+func (t *FileTree) RestoreState(state *FileTreeState) {
+	if state == nil {
+		return
+	}
+	t.updateLock.Lock()
+	defer t.updateLock.Unlock()
+
+	open := append([]widget.TreeNodeID{}, state.Open...)
+	sort.Slice(open, func(i, j int) bool {
+		return len(open[i]) < len(open[j])
+	})
+	for _, id := range open {
+		if u, err := storage.ParseURI(id); err == nil && t.IsBranch(u.String()) {
+			t.Tree.OpenBranch(t.branchID(u))
+		}
+	}
+
+	var selected []fyne.URI
+	for _, id := range state.Selected {
+		u, err := storage.ParseURI(id)
+		if err != nil {
+			continue
+		}
+		if ok, err := storage.Exists(u); ok && err == nil {
+			selected = append(selected, u)
+		}
+	}
+	t.SetSelectedURIs(selected)
+
+	t.stateLock.Lock()
+	t.restoreOffset = state.Offset
+	t.restoringOffset = true
+	t.stateLock.Unlock()
+	t.restoreScroll()
+}
+
+// Resize sets a new size for the tree, reapplying a restored scroll position that could not be reached before.
+func (t *FileTree) Resize(size fyne.Size) {
+	t.Tree.Resize(size)
+	t.restoreScroll()
+}
+
+// State returns a snapshot of the open branches, selection and scroll position that can be passed to RestoreState.
+func (t *FileTree) State() *FileTreeState {
+	state := &FileTreeState{}
+
+	t.stateLock.Lock()
+	for id := range t.opened {
+		if id != t.Root && t.IsBranchOpen(id) {
+			state.Open = append(state.Open, id)
+		}
+	}
+	t.stateLock.Unlock()
+	sort.Strings(state.Open)
+
+	for _, u := range t.SelectedURIs() {
+		state.Selected = append(state.Selected, u.String())
+	}
+
+	t.stateLock.Lock()
+	if t.scroller != nil {
+		state.Offset = t.scroller.Offset.Y
+	}
+	t.stateLock.Unlock()
+	return state
+}
+
+// restoreScroll moves to the offset of a restored state. The offset is kept until the tree has a size and
+// no branches are loading, so that it is not limited by content that is not known yet.
+func (t *FileTree) restoreScroll() {
+	t.stateLock.Lock()
+	scroller, offset, restoring := t.scroller, t.restoreOffset, t.restoringOffset
+	t.stateLock.Unlock()
+	if !restoring || scroller == nil {
+		return
+	}
+
+	scroller.Offset = fyne.NewPos(scroller.Offset.X, offset)
+	scroller.Refresh()
+
+	t.cacheLock.Lock()
+	loading := len(t.loading) > 0
+	t.cacheLock.Unlock()
+	t.refreshLock.Lock()
+	loading = loading || t.refreshPending // loaded, but not shown yet
+	t.refreshLock.Unlock()
+	if !loading && !scroller.Size().IsZero() {
+		t.stateLock.Lock()
+		t.restoringOffset = false
+		t.stateLock.Unlock()
+	}
+}
+
+// forgetOpen stops recording a branch, and the branches below it, as opened once it has been removed from the tree.
+func (t *FileTree) forgetOpen(id widget.TreeNodeID) {
+	prefix := strings.TrimSuffix(id, "/") + "/"
+	t.stateLock.Lock()
+	defer t.stateLock.Unlock()
+	for opened := range t.opened {
+		if opened == id || strings.HasPrefix(opened, prefix) {
+			delete(t.opened, opened)
+		}
+	}
+}
+
+// stateChanged saves the state after a short delay if PersistState has been called.
+func (t *FileTree) stateChanged() {
+	t.stateLock.Lock()
+	defer t.stateLock.Unlock()
+	p := t.persistence
+	if p == nil || t.restoringOffset {
+		return
+	}
+
+	if p.timer != nil {
+		p.timer.Reset(persistStateDelay)
+		return
+	}
+	p.timer = time.AfterFunc(persistStateDelay, func() {
+		t.saveState(p)
+	})
+}
+
+func (t *FileTree) saveState(p *fileTreePersistence) {
+	data, err := json.Marshal(t.State())
+	if err != nil {
+		fyne.LogError("Unable to save the state of the file tree", err)
+		return
+	}
+
+	saved := string(data)
+	t.stateLock.Lock()
+	if t.persistence != p || p.saved == saved {
+		t.stateLock.Unlock()
+		return
+	}
+	p.saved = saved
+	t.stateLock.Unlock()
+	p.preferences.SetString(p.key, saved)
+}
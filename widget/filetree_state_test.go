@@ -0,0 +1,121 @@
+package widget
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestFileTree_State(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+	for i := 0; i < 20; i++ {
+		writeFile(t, tempDir, fmt.Sprintf("B/file%02d.txt", i), "")
+	}
+
+	root := storage.NewFileURI(tempDir)
+	branchA, _ := storage.Child(root, "A")
+	branchB, _ := storage.Child(root, "B")
+	leaf, _ := storage.Child(branchB, "D.txt")
+	missing, _ := storage.Child(root, "missing")
+
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	tree.OpenAllBranches()
+	tree.CloseBranch(branchA.String())
+	tree.Select(leaf.String())
+	state := tree.State()
+	assert.Equal(t, []string{branchB.String()}, state.Open)
+	assert.Equal(t, []string{leaf.String()}, state.Selected)
+
+	state.Open = append(state.Open, missing.String())
+	state.Selected = append(state.Selected, missing.String())
+	state.Offset = 100
+	restored := NewFileTree(root)
+	defer waitForFileTree(t, restored)
+	window := test.NewWindow(restored)
+	defer window.Close()
+	waitForFileTree(t, restored)
+	window.Resize(fyne.NewSize(300, 200))
+	waitForFileTree(t, restored)
+	restored.RestoreState(state)
+	assert.Equal(t, []string{branchB.String()}, restored.State().Open) // before it has been listed
+
+	assert.True(t, restored.IsBranchOpen(branchB.String()))
+	assert.False(t, restored.IsBranchOpen(branchA.String()))
+	assert.Equal(t, []fyne.URI{leaf}, restored.SelectedURIs())
+	waitForFileTree(t, restored) // the offset is applied again once branches have loaded
+	assert.Equal(t, float32(100), restored.State().Offset)
+
+	restored.CloseAllBranches()
+	assert.Empty(t, restored.State().Open)
+}
+
+func TestFileTree_PersistState(t *testing.T) {
+	preferences := test.NewApp().Preferences()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	branch, _ := storage.Child(root, "B")
+	leaf, _ := storage.Child(branch, "C.txt")
+
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	window := test.NewWindow(tree)
+	defer window.Close()
+	waitForFileTree(t, tree)
+	tree.PersistState(preferences, "tree")
+	tree.OpenAllBranches()
+	tree.Select(leaf.String())
+
+	saved := func() *FileTreeState {
+		state := &FileTreeState{}
+		_ = json.Unmarshal([]byte(preferences.String("tree")), state)
+		return state
+	}
+	assert.Eventually(t, func() bool {
+		state := saved()
+		return len(state.Selected) == 1 && state.Selected[0] == leaf.String()
+	}, time.Second, 10*time.Millisecond)
+
+	tree.CloseBranch(branch.String()) // saved without the branch being shown again
+	assert.Eventually(t, func() bool {
+		for _, id := range saved().Open {
+			if id == branch.String() {
+				return false
+			}
+		}
+		return true
+	}, time.Second, 10*time.Millisecond)
+	tree.OpenBranch(branch.String())
+	assert.Eventually(t, func() bool {
+		for _, id := range saved().Open {
+			if id == branch.String() {
+				return true
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+	tree.PersistState(nil, "")
+
+	restored := NewFileTree(root)
+	defer waitForFileTree(t, restored)
+	restored.PersistState(preferences, "tree")
+	assert.True(t, restored.IsBranchOpen(branch.String()))
+	assert.Equal(t, []fyne.URI{leaf}, restored.SelectedURIs())
+
+	preferences.SetString("tree", "not json")
+	NewFileTree(root).PersistState(preferences, "tree") // should not crash
+}
@@ -0,0 +1,419 @@
+package widget
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// minColumnWidth is the narrowest that a column can be resized to.
+const minColumnWidth = float32(32)
+
+// FileTreeColumn describes a column of details shown after the name of each node in a FileTreeTable.
+type FileTreeColumn struct {
+	// Title is shown in the header of the column.
+	Title string
+	// Width is the initial width of the column, it can be changed by dragging the edge of the header.
+	Width float32
+	// Alignment positions the text within the column.
+	Alignment fyne.TextAlign
+	// Value returns the text to show for a URI. The metadata comes from the FileMetadataProvider
+	// for the URI scheme and is nil if it is not known.
+	Value func(u fyne.URI, metadata *FileMetadata) string
+	// Sorter orders the nodes when the header is tapped, if it is nil the column cannot be sorted.
+	Sorter func(fyne.URI, fyne.URI) bool
+}
+
+// NewFileModifiedColumn returns a column showing the date and time each file was last modified.
+func NewFileModifiedColumn() *FileTreeColumn {
+	return &FileTreeColumn{Title: "Modified", Width: 140, Sorter: SortByModified,
+		Value: func(_ fyne.URI, m *FileMetadata) string {
+			if m == nil || m.Modified.IsZero() {
+				return ""
+			}
+			return m.Modified.Format("2006-01-02 15:04")
+		}}
+}
+
+// NewFileSizeColumn returns a column showing the size of each file, it is empty for folders.
+func NewFileSizeColumn() *FileTreeColumn {
+	return &FileTreeColumn{Title: "Size", Width: 80, Alignment: fyne.TextAlignTrailing, Sorter: SortBySize,
+		Value: func(_ fyne.URI, m *FileMetadata) string {
+			if m == nil || m.Size < 0 {
+				return ""
+			}
+			return formatFileSize(m.Size)
+		}}
+}
+
+// NewFileTypeColumn returns a column showing the MIME type of each file.
+func NewFileTypeColumn() *FileTreeColumn {
+	return &FileTreeColumn{Title: "Type", Width: 140,
+		Sorter: func(a, b fyne.URI) bool {
+			typeA, typeB := a.MimeType(), b.MimeType()
+			if ma, mb := fileMetadata(a), fileMetadata(b); ma != nil && mb != nil {
+				typeA, typeB = ma.MimeType, mb.MimeType
+			}
+			if typeA != typeB {
+				return typeA < typeB
+			}
+			return SortByName(a, b)
+		},
+		Value: func(u fyne.URI, m *FileMetadata) string {
+			if m == nil {
+				return u.MimeType()
+			}
+			return m.MimeType
+		}}
+}
+
+// FileTreeTable shows a FileTree with columns of details, such as size and modification time,
+// under a header that can be tapped to sort the nodes and dragged to resize the columns.
+type FileTreeTable struct {
+	widget.BaseWidget
+	Tree *FileTree
+
+	columns    []*FileTreeColumn
+	header     *fyne.Container
+	sortColumn int
+	descending bool
+}
+
+// NewFileTreeTable creates a new table of the files below the root URI, showing the given columns after the name.
+// If no columns are passed the size, modification time and type are shown.
+func NewFileTreeTable(root fyne.URI, columns ...*FileTreeColumn) *FileTreeTable {
+	if len(columns) == 0 {
+		columns = []*FileTreeColumn{NewFileSizeColumn(), NewFileModifiedColumn(), NewFileTypeColumn()}
+	}
+	t := &FileTreeTable{Tree: NewFileTree(root), columns: columns, sortColumn: -1}
+	t.Tree.table = t
+
+	objects := []fyne.CanvasObject{newFileTreeHeaderCell(t, 0, "Name")}
+	for i, col := range columns {
+		objects = append(objects, newFileTreeHeaderCell(t, i+1, col.Title), newFileTreeColumnResizer(t, i))
+	}
+	t.header = container.New(&fileTreeHeaderLayout{table: t}, objects...)
+	t.ExtendBaseWidget(t)
+	return t
+}
+
+// CreateRenderer is a private method to Fyne which links this widget to its renderer.
+func (t *FileTreeTable) CreateRenderer() fyne.WidgetRenderer {
+	line := canvas.NewRectangle(theme.ShadowColor())
+	return &fileTreeTableRenderer{table: t, line: line, objects: []fyne.CanvasObject{t.header, line, t.Tree}}
+}
+
+// ColumnWidth returns the current width of a detail column.
+func (t *FileTreeTable) ColumnWidth(column int) float32 {
+	return t.columns[column].Width
+}
+
+// SetColumnWidth changes the width of a detail column, the first detail column is 0.
+func (t *FileTreeTable) SetColumnWidth(column int, width float32) {
+	if width < minColumnWidth {
+		width = minColumnWidth
+	}
+	t.columns[column].Width = width
+	t.header.Refresh()
+	t.Tree.Refresh()
+}
+
+// SortBy orders the nodes using a column, where 0 is the name and 1 is the first detail column.
+// Folders are always shown before files. Columns without a sorter are ignored.
+func (t *FileTreeTable) SortBy(column int, descending bool) {
+	sorter := SortByName
+	if column > 0 {
+		if sorter = t.columns[column-1].Sorter; sorter == nil {
+			return
+		}
+	}
+	if descending {
+		sorter = SortDescending(sorter)
+	}
+
+	t.sortColumn, t.descending = column, descending
+	t.Tree.SetSorter(SortDirectoriesFirst(sorter))
+	t.header.Refresh()
+}
+
+// columnsWidth returns the total width of the detail columns.
+func (t *FileTreeTable) columnsWidth() (width float32) {
+	for _, col := range t.columns {
+		width += col.Width
+	}
+	return width
+}
+
+// toggleSort sorts by a column when its header is tapped, reversing the order if it was already sorted by it.
+func (t *FileTreeTable) toggleSort(column int) {
+	t.SortBy(column, column == t.sortColumn && !t.descending)
+}
+
+type fileTreeTableRenderer struct {
+	table   *FileTreeTable
+	line    *canvas.Rectangle
+	objects []fyne.CanvasObject
+}
+
+func (r *fileTreeTableRenderer) Destroy() {
+}
+
+func (r *fileTreeTableRenderer) Layout(size fyne.Size) {
+	headerHeight := r.table.header.MinSize().Height
+	r.table.header.Resize(fyne.NewSize(size.Width, headerHeight))
+	r.line.Move(fyne.NewPos(0, headerHeight))
+	r.line.Resize(fyne.NewSize(size.Width, theme.SeparatorThicknessSize()))
+	top := headerHeight + theme.SeparatorThicknessSize()
+	r.table.Tree.Move(fyne.NewPos(0, top))
+	r.table.Tree.Resize(fyne.NewSize(size.Width, size.Height-top))
+}
+
+func (r *fileTreeTableRenderer) MinSize() fyne.Size {
+	header := r.table.header.MinSize()
+	tree := r.table.Tree.MinSize()
+	return fyne.NewSize(fyne.Max(header.Width, tree.Width), header.Height+theme.SeparatorThicknessSize()+tree.Height)
+}
+
+func (r *fileTreeTableRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *fileTreeTableRenderer) Refresh() {
+	r.line.FillColor = theme.ShadowColor()
+	r.line.Refresh()
+	r.table.header.Refresh()
+	r.table.Tree.Refresh()
+}
+
+// fileTreeHeaderLayout places the header cells over the columns of the nodes below.
+// The detail columns line up with the end of each row and the name takes the remaining space.
+type fileTreeHeaderLayout struct {
+	table *FileTreeTable
+}
+
+func (l *fileTreeHeaderLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	x := size.Width - theme.Padding() - l.table.columnsWidth()
+	objects[0].Move(fyne.NewPos(0, 0))
+	objects[0].Resize(fyne.NewSize(x, size.Height))
+
+	handle := theme.Padding() * 2
+	for i, col := range l.table.columns {
+		cell, resizer := objects[1+i*2], objects[2+i*2]
+		cell.Move(fyne.NewPos(x, 0))
+		cell.Resize(fyne.NewSize(col.Width, size.Height))
+		resizer.Move(fyne.NewPos(x-handle/2, 0))
+		resizer.Resize(fyne.NewSize(handle, size.Height))
+		x += col.Width
+	}
+}
+
+func (l *fileTreeHeaderLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	min := objects[0].MinSize()
+	for _, o := range objects[1:] {
+		min.Height = fyne.Max(min.Height, o.MinSize().Height)
+	}
+	return fyne.NewSize(min.Width+l.table.columnsWidth()+theme.Padding(), min.Height)
+}
+
+// fileTreeColumnsLayout places the detail cells of a node at the widths of the table columns.
+type fileTreeColumnsLayout struct {
+	tree *FileTree
+}
+
+func (l *fileTreeColumnsLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	table := l.tree.table
+	if table == nil {
+		return
+	}
+	x := float32(0)
+	for i, o := range objects {
+		width := table.columns[i].Width
+		min := o.MinSize()
+		textX := x + theme.Padding()
+		switch o.(*canvas.Text).Alignment {
+		case fyne.TextAlignTrailing:
+			textX = x + width - theme.Padding() - min.Width
+		case fyne.TextAlignCenter:
+			textX = x + (width-min.Width)/2
+		}
+
+		o.Move(fyne.NewPos(textX, (size.Height-min.Height)/2))
+		o.Resize(fyne.NewSize(fyne.Min(min.Width, width-2*theme.Padding()), min.Height))
+		x += width
+	}
+}
+
+func (l *fileTreeColumnsLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	if l.tree.table == nil {
+		return fyne.NewSize(0, 0)
+	}
+	height := float32(0)
+	for _, o := range objects {
+		height = fyne.Max(height, o.MinSize().Height)
+	}
+	return fyne.NewSize(l.tree.table.columnsWidth(), height)
+}
+
+// fileTreeHeaderCell shows the title of a column and sorts the table by the column when tapped.
+type fileTreeHeaderCell struct {
+	widget.BaseWidget
+	table  *FileTreeTable
+	column int
+	title  string
+}
+
+func newFileTreeHeaderCell(table *FileTreeTable, column int, title string) *fileTreeHeaderCell {
+	c := &fileTreeHeaderCell{table: table, column: column, title: title}
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+func (c *fileTreeHeaderCell) CreateRenderer() fyne.WidgetRenderer {
+	text := canvas.NewText(c.title, theme.TextColor())
+	text.TextStyle.Bold = true
+	icon := widget.NewIcon(nil)
+	return &fileTreeHeaderCellRenderer{cell: c, text: text, icon: icon}
+}
+
+// Tapped sorts the table by this column, or reverses the order if it is already sorted by it.
+//
+// Implements: fyne.Tappable
+func (c *fileTreeHeaderCell) Tapped(*fyne.PointEvent) {
+	c.table.toggleSort(c.column)
+}
+
+type fileTreeHeaderCellRenderer struct {
+	cell *fileTreeHeaderCell
+	text *canvas.Text
+	icon *widget.Icon
+}
+
+func (r *fileTreeHeaderCellRenderer) Destroy() {
+}
+
+func (r *fileTreeHeaderCellRenderer) Layout(size fyne.Size) {
+	min := r.text.MinSize()
+	iconSize := theme.IconInlineSize()
+	x := theme.Padding()
+	if r.cell.column > 0 && r.cell.table.columns[r.cell.column-1].Alignment == fyne.TextAlignTrailing {
+		x = size.Width - theme.Padding() - min.Width
+		if r.icon.Visible() {
+			x -= iconSize
+		}
+	}
+	r.text.Move(fyne.NewPos(x, (size.Height-min.Height)/2))
+	r.text.Resize(min)
+	r.icon.Move(fyne.NewPos(x+min.Width, (size.Height-iconSize)/2))
+	r.icon.Resize(fyne.NewSize(iconSize, iconSize))
+}
+
+func (r *fileTreeHeaderCellRenderer) MinSize() fyne.Size {
+	min := r.text.MinSize()
+	return fyne.NewSize(min.Width+theme.IconInlineSize()+theme.Padding()*2, min.Height+theme.Padding()*2)
+}
+
+func (r *fileTreeHeaderCellRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.text, r.icon}
+}
+
+func (r *fileTreeHeaderCellRenderer) Refresh() {
+	r.text.Color = theme.TextColor()
+	r.text.Refresh()
+
+	table := r.cell.table
+	if table.sortColumn != r.cell.column {
+		r.icon.Hide()
+	} else {
+		if table.descending {
+			r.icon.SetResource(theme.MenuDropDownIcon())
+		} else {
+			r.icon.SetResource(theme.MenuDropUpIcon())
+		}
+		r.icon.Show()
+	}
+	r.Layout(r.cell.Size())
+}
+
+// fileTreeColumnResizer is the handle at the start of a detail column that is dragged to change its width.
+type fileTreeColumnResizer struct {
+	widget.BaseWidget
+	table  *FileTreeTable
+	column int
+}
+
+func newFileTreeColumnResizer(table *FileTreeTable, column int) *fileTreeColumnResizer {
+	r := &fileTreeColumnResizer{table: table, column: column}
+	r.ExtendBaseWidget(r)
+	return r
+}
+
+func (r *fileTreeColumnResizer) CreateRenderer() fyne.WidgetRenderer {
+	line := canvas.NewRectangle(theme.ShadowColor())
+	return &fileTreeColumnResizerRenderer{line: line}
+}
+
+// Cursor shows that the column can be resized.
+//
+// Implements: desktop.Cursorable
+func (r *fileTreeColumnResizer) Cursor() desktop.Cursor {
+	return desktop.HResizeCursor
+}
+
+// Dragged changes the width of the column, dragging to the left makes it wider as columns are aligned to the end.
+//
+// Implements: fyne.Draggable
+func (r *fileTreeColumnResizer) Dragged(ev *fyne.DragEvent) {
+	r.table.SetColumnWidth(r.column, r.table.ColumnWidth(r.column)-ev.Dragged.DX)
+}
+
+// DragEnd is called when the resize has finished.
+//
+// Implements: fyne.Draggable
+func (r *fileTreeColumnResizer) DragEnd() {
+}
+
+type fileTreeColumnResizerRenderer struct {
+	line *canvas.Rectangle
+}
+
+func (r *fileTreeColumnResizerRenderer) Destroy() {
+}
+
+func (r *fileTreeColumnResizerRenderer) Layout(size fyne.Size) {
+	thickness := theme.SeparatorThicknessSize()
+	r.line.Move(fyne.NewPos((size.Width-thickness)/2, theme.Padding()))
+	r.line.Resize(fyne.NewSize(thickness, size.Height-theme.Padding()*2))
+}
+
+func (r *fileTreeColumnResizerRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(theme.Padding()*2, 0)
+}
+
+func (r *fileTreeColumnResizerRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.line}
+}
+
+func (r *fileTreeColumnResizerRenderer) Refresh() {
+	r.line.FillColor = theme.ShadowColor()
+	r.line.Refresh()
+}
+
+// formatFileSize returns a size in bytes as a short string using binary units, such as "1.5 KB".
+func formatFileSize(size int64) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size)
+	for _, unit := range []string{"KB", "MB", "GB", "TB"} {
+		value /= 1024
+		if value < 1024 || unit == "TB" {
+			return fmt.Sprintf("%.1f %s", value, unit)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,92 @@
+package widget
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+)
+
+type testMetadataProvider struct{}
+
+func (testMetadataProvider) Metadata(u fyne.URI) (*FileMetadata, error) {
+	if u.Name() == "missing" {
+		return nil, errors.New("not found")
+	}
+	return &FileMetadata{Size: 2048, Modified: time.Date(2021, 3, 4, 5, 6, 0, 0, time.UTC), MimeType: "test/data"}, nil
+}
+
+func TestFileTreeTable(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+	writeFile(t, tempDir, "B/E.txt", "longer content")
+
+	root := storage.NewFileURI(tempDir)
+	table := NewFileTreeTable(root)
+	defer waitForFileTree(t, table.Tree)
+	table.Tree.OpenAllBranches()
+	window := test.NewWindow(table)
+	defer window.Close()
+	waitForFileTree(t, table.Tree)
+	window.Resize(fyne.NewSize(600, 300))
+	waitForFileTree(t, table.Tree)
+
+	branch, _ := storage.Child(root, "B")
+	leaf, _ := storage.Child(branch, "C.txt")
+	cells := findFileTreeNode(table.Tree, leaf.String()).cells.Objects
+	assert.Equal(t, 3, len(cells))
+	assert.Equal(t, "1 B", cells[0].(*canvas.Text).Text)
+	assert.Equal(t, "text/plain", cells[2].(*canvas.Text).Text)
+	assert.Equal(t, "", findFileTreeNode(table.Tree, branch.String()).cells.Objects[0].(*canvas.Text).Text)
+
+	header := table.header.Objects
+	test.Tap(header[1].(*fileTreeHeaderCell)) // size
+	names := func() (names []string) {
+		for _, id := range table.Tree.ChildUIDs(branch.String()) {
+			u, _ := storage.ParseURI(id)
+			names = append(names, u.Name())
+		}
+		return names
+	}
+	assert.Equal(t, []string{"C.txt", "D.txt", "E.txt"}, names())
+	test.Tap(header[1].(*fileTreeHeaderCell))
+	assert.Equal(t, []string{"E.txt", "D.txt", "C.txt"}, names())
+	test.Tap(header[0].(*fileTreeHeaderCell)) // name
+	assert.Equal(t, []string{"C.txt", "D.txt", "E.txt"}, names())
+
+	resizer := header[2].(*fileTreeColumnResizer)
+	resizer.Dragged(&fyne.DragEvent{Dragged: fyne.NewDelta(-20, 0)})
+	assert.Equal(t, float32(100), table.ColumnWidth(0))
+	table.SetColumnWidth(0, 1)
+	assert.Equal(t, minColumnWidth, table.ColumnWidth(0))
+}
+
+func TestFileMetadataProvider(t *testing.T) {
+	RegisterFileMetadataProvider("fyne-x-test", testMetadataProvider{})
+	defer RegisterFileMetadataProvider("fyne-x-test", nil)
+
+	u, _ := storage.ParseURI("fyne-x-test:///folder/file")
+	assert.Equal(t, "2.0 KB", NewFileSizeColumn().Value(u, fileMetadata(u)))
+	assert.Equal(t, "2021-03-04 05:06", NewFileModifiedColumn().Value(u, fileMetadata(u)))
+	assert.Equal(t, "test/data", NewFileTypeColumn().Value(u, fileMetadata(u)))
+
+	missing, _ := storage.ParseURI("fyne-x-test:///folder/missing")
+	assert.Nil(t, fileMetadata(missing))
+	assert.True(t, SortBySize(u, missing))
+}
+
+func TestFormatFileSize(t *testing.T) {
+	assert.Equal(t, "0 B", formatFileSize(0))
+	assert.Equal(t, "1023 B", formatFileSize(1023))
+	assert.Equal(t, "1.5 KB", formatFileSize(1536))
+	assert.Equal(t, "3.0 GB", formatFileSize(3<<30))
+}
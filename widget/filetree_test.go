@@ -5,6 +5,7 @@ import (
 	"os"
 	"path"
 	"testing"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/storage"
@@ -27,11 +28,14 @@ func TestFileTree_Layout(t *testing.T) {
 	root, err := storage.ParseURI("file://" + tempDir)
 	assert.NoError(t, err)
 	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
 	tree.OpenAllBranches()
 
 	window := test.NewWindow(tree)
 	defer window.Close()
+	waitForFileTree(t, tree)
 	window.Resize(fyne.NewSize(300, 100))
+	waitForFileTree(t, tree)
 
 	branch, err := storage.Child(root, "B")
 	assert.NoError(t, err)
@@ -49,6 +53,7 @@ func TestFileTree_filter(t *testing.T) {
 	root, err := storage.ParseURI("file://" + tempDir)
 	assert.NoError(t, err)
 	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
 	tree.Filter = storage.NewExtensionFileFilter([]string{".txt"})
 
 	branch1, err := storage.Child(root, "A")
@@ -82,6 +87,7 @@ func TestFileTree_sort(t *testing.T) {
 	root, err := storage.ParseURI("file://" + tempDir)
 	assert.NoError(t, err)
 	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
 	tree.Sorter = func(u1, u2 fyne.URI) bool {
 		return u2.String() < u1.String() // Reverse alphabetical
 	}
@@ -121,6 +127,7 @@ func Test_NewFileTree(t *testing.T) {
 	root, err := storage.ParseURI("file://" + tempDir)
 	assert.NoError(t, err)
 	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
 	tree.OpenAllBranches()
 
 	assert.True(t, tree.IsBranchOpen(root.String()))
@@ -135,6 +142,148 @@ func Test_NewFileTree(t *testing.T) {
 	assert.False(t, tree.IsBranchOpen(leaf.String()))
 }
 
+func TestFileTree_ChildUIDs(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root, err := storage.ParseURI("file://" + tempDir)
+	assert.NoError(t, err)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	branch, err := storage.Child(root, "B")
+	assert.NoError(t, err)
+	leaf1, err := storage.Child(branch, "C.txt")
+	assert.NoError(t, err)
+	leaf2, err := storage.Child(branch, "D.txt")
+	assert.NoError(t, err)
+
+	test.WidgetRenderer(tree) // lists the root
+	waitForFileTree(t, tree)
+	loading := branch.String() + loadingNodeSuffix
+	tree.OpenBranch(branch.String())
+	assert.Equal(t, []string{loading}, tree.ChildUIDs(branch.String()))
+	assert.False(t, tree.IsBranch(loading))
+	assert.Eventually(t, func() bool {
+		return len(tree.ChildUIDs(branch.String())) == 2
+	}, time.Second, time.Millisecond*10)
+	assert.ElementsMatch(t, []string{leaf1.String(), leaf2.String()}, tree.ChildUIDs(branch.String()))
+}
+
+func TestFileTree_ChildUIDs_Cancel(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root, err := storage.ParseURI("file://" + tempDir)
+	assert.NoError(t, err)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	branch, err := storage.Child(root, "B")
+	assert.NoError(t, err)
+
+	// occupy all of the workers so the listing has to wait
+	for i := 0; i < maxListWorkers; i++ {
+		tree.workers <- struct{}{}
+	}
+	tree.OpenBranch(branch.String())
+	tree.ChildUIDs(branch.String())
+	tree.CloseBranch(branch.String())
+	for i := 0; i < maxListWorkers; i++ {
+		<-tree.workers
+	}
+
+	assert.Eventually(t, func() bool {
+		tree.cacheLock.Lock()
+		defer tree.cacheLock.Unlock()
+		_, loading := tree.loading[branch.String()]
+		return !loading
+	}, time.Second, time.Millisecond*10)
+	_, listed := tree.childCache[branch.String()]
+	assert.False(t, listed)
+}
+
+func TestFileTree_Destroy(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	branch, _ := storage.Child(root, "B")
+	renderer := test.WidgetRenderer(tree)
+
+	for i := 0; i < maxListWorkers; i++ {
+		tree.workers <- struct{}{}
+	}
+	tree.OpenBranch(branch.String())
+	tree.ChildUIDs(branch.String())
+	renderer.Destroy()
+	for i := 0; i < maxListWorkers; i++ {
+		<-tree.workers
+	}
+
+	waitForFileTree(t, tree)
+	assert.Empty(t, tree.loading)
+	_, listed := tree.childCache[branch.String()]
+	assert.False(t, listed)
+}
+
+func TestFileTree_CloseAllBranches(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	branchA, _ := storage.Child(root, "A")
+	branchB, _ := storage.Child(root, "B")
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	tree.listAll(root.String())
+	tree.OpenBranch(branchA.String())
+	watcher := &recordingWatcher{events: make(chan fyne.URI)}
+	tree.SetWatcher(watcher)
+	defer test.WidgetRenderer(tree).Destroy()
+	assert.ElementsMatch(t, []string{root.String(), branchA.String()}, watcher.watching())
+
+	for i := 0; i < maxListWorkers; i++ {
+		tree.workers <- struct{}{}
+	}
+	tree.Reload() // list the branches again, waiting for a worker
+	tree.ChildUIDs(branchB.String())
+	tree.OpenBranch(branchB.String())
+	tree.CloseAllBranches()
+	tree.cacheLock.Lock()
+	_, loadingA := tree.loading[branchA.String()]
+	_, loadingB := tree.loading[branchB.String()]
+	tree.cacheLock.Unlock()
+	assert.False(t, loadingA)
+	assert.False(t, loadingB)
+	for i := 0; i < maxListWorkers; i++ {
+		<-tree.workers
+	}
+
+	waitForFileTree(t, tree)
+	assert.Equal(t, []string{root.String()}, watcher.watching())
+	_, listed := tree.childCache[branchB.String()]
+	assert.False(t, listed)
+}
+
+// waitForFileTree waits until the tree has finished listing branches and refreshing in the background.
+func waitForFileTree(t *testing.T, tree *FileTree) {
+	t.Helper()
+	assert.Eventually(t, func() bool {
+		tree.cacheLock.Lock()
+		defer tree.cacheLock.Unlock()
+		return tree.background == 0
+	}, time.Second, time.Millisecond*10)
+}
+
 func createTempDir(t *testing.T) string {
 	t.Helper()
 	tempDir, err := ioutil.TempDir("", "test")
@@ -0,0 +1,321 @@
+package widget
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+const defaultWatchInterval = time.Second
+
+// FileWatcher reports changes to the contents of the listable URIs shown by a FileTree.
+type FileWatcher interface {
+	// Watch starts reporting changes to the direct children of the given URI.
+	Watch(fyne.ListableURI) error
+	// Unwatch stops reporting changes for a URI that was previously watched.
+	Unwatch(fyne.URI) error
+	// Events returns a channel that receives each watched URI whose children have changed.
+	Events() <-chan fyne.URI
+	// Close stops all watches and closes the events channel.
+	Close() error
+}
+
+// NewPollingFileWatcher returns a FileWatcher that lists each watched URI at the given interval
+// and reports any that have changed. It works for any URI that can be listed.
+func NewPollingFileWatcher(interval time.Duration) FileWatcher {
+	return newPollingWatcher(interval, make(chan fyne.URI, 16))
+}
+
+type pollingWatcher struct {
+	events chan fyne.URI
+	done   chan struct{}
+
+	lock    sync.Mutex
+	watched map[string]*polledURI
+}
+
+type polledURI struct {
+	uri      fyne.ListableURI
+	snapshot string
+}
+
+func newPollingWatcher(interval time.Duration, events chan fyne.URI) *pollingWatcher {
+	w := &pollingWatcher{events: events, done: make(chan struct{}), watched: make(map[string]*polledURI)}
+	go w.run(interval)
+	return w
+}
+
+func (w *pollingWatcher) Watch(u fyne.ListableURI) error {
+	snapshot, err := listingSnapshot(u)
+	if err != nil {
+		return err
+	}
+
+	w.lock.Lock()
+	w.watched[u.String()] = &polledURI{uri: u, snapshot: snapshot}
+	w.lock.Unlock()
+	return nil
+}
+
+func (w *pollingWatcher) Unwatch(u fyne.URI) error {
+	w.lock.Lock()
+	delete(w.watched, u.String())
+	w.lock.Unlock()
+	return nil
+}
+
+func (w *pollingWatcher) Events() <-chan fyne.URI {
+	return w.events
+}
+
+func (w *pollingWatcher) Close() error {
+	close(w.done)
+	return nil
+}
+
+func (w *pollingWatcher) poll() {
+	w.lock.Lock()
+	var changed []fyne.URI
+	for id, p := range w.watched {
+		snapshot, err := listingSnapshot(p.uri)
+		if err != nil { // it has gone away, the change will be reported by the parent
+			delete(w.watched, id)
+			changed = append(changed, p.uri)
+			continue
+		}
+		if snapshot != p.snapshot {
+			p.snapshot = snapshot
+			changed = append(changed, p.uri)
+		}
+	}
+	w.lock.Unlock()
+
+	for _, u := range changed {
+		select {
+		case w.events <- u:
+		case <-w.done:
+			return
+		}
+	}
+}
+
+func (w *pollingWatcher) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.poll()
+		case <-w.done:
+			close(w.events)
+			return
+		}
+	}
+}
+
+func listingSnapshot(u fyne.ListableURI) (string, error) {
+	children, err := u.List()
+	if err != nil {
+		return "", err
+	}
+
+	names := make([]string, len(children))
+	for i, c := range children {
+		names[i] = c.String()
+	}
+	return strings.Join(names, "\n"), nil
+}
+
+// SetWatcher sets a FileWatcher that the tree will use to refresh branches when their contents change.
+// Any previous watcher is closed, passing nil will stop watching for changes.
+// The watcher is also closed when the tree is destroyed, such as when its window is closed.
+func (t *FileTree) SetWatcher(w FileWatcher) {
+	t.stopWatching()
+	if w == nil {
+		return
+	}
+
+	t.cacheLock.Lock()
+	t.watcher, t.watcherDone = w, make(chan struct{})
+	t.watched = make(map[widget.TreeNodeID]fyne.URI)
+	go t.watchEvents(w, t.watcherDone)
+	var listed []widget.TreeNodeID
+	for id := range t.childCache {
+		listed = append(listed, id)
+	}
+	t.cacheLock.Unlock()
+
+	for _, id := range listed {
+		if !t.IsBranchOpen(id) || t.isWorkspaceRoot(id) {
+			continue
+		}
+		if listable, err := t.toListable(id); err == nil {
+			t.watch(id, listable)
+		}
+	}
+}
+
+// invalidate removes cached information for every node below the given branch, and its list of children.
+// Branches below it are no longer watched, and those being listed in the background are cancelled.
+func (t *FileTree) invalidate(id widget.TreeNodeID) {
+	prefix := id
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	t.cacheLock.Lock()
+	for loading, cancel := range t.loading {
+		if loading == id || strings.HasPrefix(loading, prefix) {
+			close(cancel)
+			delete(t.loading, loading)
+		}
+	}
+	delete(t.childCache, id)
+	for child := range t.childCache {
+		if strings.HasPrefix(child, prefix) {
+			delete(t.childCache, child)
+		}
+	}
+	delete(t.errors, id)
+	for child := range t.errors {
+		if strings.HasPrefix(child, prefix) {
+			delete(t.errors, child)
+		}
+	}
+	t.listableCache.removePrefix(prefix)
+	t.uriCache.removePrefix(prefix)
+	t.cacheLock.Unlock()
+
+	t.unwatch(func(child widget.TreeNodeID) bool {
+		return strings.HasPrefix(child, prefix)
+	})
+}
+
+// reload lists a branch again after the watcher, or an operation, reports that it changed. Cached information is kept for the
+// children that still exist, and the tree is refreshed once the new children are known. The branch is listed with the
+// filter and sorter of the tree when the reload starts.
+func (t *FileTree) reload(id widget.TreeNodeID, o fileListOptions) {
+	t.cacheLock.Lock()
+	old, listed := t.childCache[id]
+	t.cacheLock.Unlock()
+	if !listed {
+		return
+	}
+
+	children := t.listChildren(id, o)
+	t.cacheLock.Lock()
+	if _, listed = t.childCache[id]; !listed { // closed or removed while listing
+		t.cacheLock.Unlock()
+		return
+	}
+	t.childCache[id] = children
+	t.cacheLock.Unlock()
+
+	kept := make(map[widget.TreeNodeID]bool, len(children))
+	for _, child := range children {
+		kept[child] = true
+	}
+	for _, child := range old {
+		if kept[child] || isPlaceholderNode(child) {
+			continue
+		}
+		t.invalidate(child)
+		t.cacheLock.Lock()
+		t.listableCache.remove(child)
+		t.uriCache.remove(child)
+		t.cacheLock.Unlock()
+		t.unwatch(func(watched widget.TreeNodeID) bool {
+			return watched == child
+		})
+		t.forgetOpen(child)
+	}
+	t.refreshLoaded()
+}
+
+// reloadInBackground lists branches again, after the tree has changed files inside them.
+func (t *FileTree) reloadInBackground(ids []widget.TreeNodeID) {
+	o := t.listOptions()
+	t.cacheLock.Lock()
+	t.background++
+	t.cacheLock.Unlock()
+
+	go func() {
+		defer t.backgroundDone()
+		for _, id := range ids {
+			t.reload(id, o)
+		}
+	}()
+}
+
+// stopWatching closes the watcher, after it has been told to stop watching each branch.
+func (t *FileTree) stopWatching() {
+	t.unwatch(func(widget.TreeNodeID) bool {
+		return true
+	})
+
+	t.cacheLock.Lock()
+	w, done := t.watcher, t.watcherDone
+	t.watcher, t.watcherDone = nil, nil
+	t.cacheLock.Unlock()
+	if w != nil {
+		close(done)
+		_ = w.Close()
+	}
+}
+
+// unwatch stops watching the branches that match.
+func (t *FileTree) unwatch(match func(widget.TreeNodeID) bool) {
+	var uris []fyne.URI
+	t.cacheLock.Lock()
+	w := t.watcher
+	for id, u := range t.watched {
+		if match(id) {
+			uris = append(uris, u)
+			delete(t.watched, id)
+		}
+	}
+	t.cacheLock.Unlock()
+	if w == nil {
+		return
+	}
+
+	for _, u := range uris {
+		if err := w.Unwatch(u); err != nil {
+			fyne.LogError("Unable to unwatch "+u.String(), err)
+		}
+	}
+}
+
+// watch requests that the watcher, if set, reports changes to the children of the branch.
+func (t *FileTree) watch(id widget.TreeNodeID, listable fyne.ListableURI) {
+	t.cacheLock.Lock()
+	w := t.watcher
+	if _, watched := t.watched[id]; w == nil || watched {
+		t.cacheLock.Unlock()
+		return
+	}
+	t.watched[id] = listable
+	t.cacheLock.Unlock()
+
+	if err := w.Watch(listable); err != nil {
+		fyne.LogError("Unable to watch "+id, err)
+	}
+}
+
+func (t *FileTree) watchEvents(w FileWatcher, done chan struct{}) {
+	events := w.Events()
+	for {
+		select {
+		case u, ok := <-events:
+			if !ok {
+				return
+			}
+			t.reload(t.branchID(u), t.listOptions())
+		case <-done:
+			return
+		}
+	}
+}
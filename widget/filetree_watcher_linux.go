@@ -0,0 +1,155 @@
+// +build linux
+
+package widget
+
+import (
+	"os"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"fyne.io/fyne/v2"
+)
+
+const inotifyMask = syscall.IN_CREATE | syscall.IN_DELETE | syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO |
+	syscall.IN_DELETE_SELF | syscall.IN_MOVE_SELF
+
+// NewFileWatcher returns a FileWatcher using the most efficient method available on the current platform.
+// On Linux local files are watched using inotify and other URIs fall back to polling.
+func NewFileWatcher() FileWatcher {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		fyne.LogError("Unable to start inotify, falling back to polling", err)
+		return NewPollingFileWatcher(defaultWatchInterval)
+	}
+
+	w := &inotifyWatcher{
+		fd:       fd,
+		file:     os.NewFile(uintptr(fd), "inotify"),
+		events:   make(chan fyne.URI, 16),
+		done:     make(chan struct{}),
+		fallback: newPollingWatcher(defaultWatchInterval, make(chan fyne.URI, 16)),
+		watches:  make(map[int32]fyne.URI),
+		paths:    make(map[string]int32),
+	}
+	w.running.Add(2)
+	go w.read()
+	go w.forward()
+	go func() {
+		w.running.Wait()
+		close(w.events)
+	}()
+	return w
+}
+
+type inotifyWatcher struct {
+	fd       int
+	file     *os.File
+	events   chan fyne.URI
+	done     chan struct{}
+	fallback *pollingWatcher
+	running  sync.WaitGroup
+
+	lock    sync.Mutex
+	watches map[int32]fyne.URI
+	paths   map[string]int32
+}
+
+func (w *inotifyWatcher) Watch(u fyne.ListableURI) error {
+	if u.Scheme() != "file" {
+		return w.fallback.Watch(u)
+	}
+
+	wd, err := syscall.InotifyAddWatch(w.fd, u.Path(), inotifyMask)
+	if err != nil {
+		return err
+	}
+
+	w.lock.Lock()
+	w.watches[int32(wd)] = u
+	w.paths[u.String()] = int32(wd)
+	w.lock.Unlock()
+	return nil
+}
+
+func (w *inotifyWatcher) Unwatch(u fyne.URI) error {
+	w.lock.Lock()
+	wd, ok := w.paths[u.String()]
+	if ok {
+		delete(w.paths, u.String())
+		delete(w.watches, wd)
+	}
+	w.lock.Unlock()
+
+	if !ok {
+		return w.fallback.Unwatch(u)
+	}
+	_, err := syscall.InotifyRmWatch(w.fd, uint32(wd))
+	return err
+}
+
+func (w *inotifyWatcher) Events() <-chan fyne.URI {
+	return w.events
+}
+
+func (w *inotifyWatcher) Close() error {
+	close(w.done)
+	w.fallback.Close()
+	return w.file.Close()
+}
+
+func (w *inotifyWatcher) forward() {
+	defer w.running.Done()
+	for u := range w.fallback.Events() {
+		if !w.send(u) {
+			return
+		}
+	}
+}
+
+func (w *inotifyWatcher) read() {
+	defer w.running.Done()
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+	for {
+		n, err := w.file.Read(buf)
+		if err != nil {
+			return
+		}
+
+		// report each watch once per read, even if many children changed
+		var changed []fyne.URI
+		seen := make(map[int32]bool)
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+			event := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			offset += syscall.SizeofInotifyEvent + int(event.Len)
+
+			w.lock.Lock()
+			u, ok := w.watches[event.Wd]
+			if ok && event.Mask&syscall.IN_IGNORED != 0 { // the watch was removed by the system
+				delete(w.watches, event.Wd)
+				delete(w.paths, u.String())
+			}
+			w.lock.Unlock()
+
+			if ok && !seen[event.Wd] {
+				seen[event.Wd] = true
+				changed = append(changed, u)
+			}
+		}
+
+		for _, u := range changed {
+			if !w.send(u) {
+				return
+			}
+		}
+	}
+}
+
+func (w *inotifyWatcher) send(u fyne.URI) bool {
+	select {
+	case w.events <- u:
+		return true
+	case <-w.done:
+		return false
+	}
+}
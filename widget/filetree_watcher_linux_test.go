@@ -0,0 +1,20 @@
+// +build linux
+
+package widget
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFileWatcher_Inotify(t *testing.T) {
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	w := NewFileWatcher()
+	_, ok := w.(*inotifyWatcher)
+	assert.True(t, ok)
+	testFileWatcher(t, w, tempDir)
+}
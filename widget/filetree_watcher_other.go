@@ -0,0 +1,9 @@
+// +build !linux
+
+package widget
+
+// NewFileWatcher returns a FileWatcher using the most efficient method available on the current platform.
+// On this platform all URIs are watched by polling.
+func NewFileWatcher() FileWatcher {
+	return NewPollingFileWatcher(defaultWatchInterval)
+}
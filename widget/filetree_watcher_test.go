@@ -0,0 +1,192 @@
+package widget
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestPollingFileWatcher(t *testing.T) {
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	testFileWatcher(t, NewPollingFileWatcher(time.Millisecond*10), tempDir)
+}
+
+func TestFileTree_SetWatcher(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root, err := storage.ParseURI("file://" + tempDir)
+	assert.NoError(t, err)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	tree.SetWatcher(NewPollingFileWatcher(time.Millisecond * 10))
+	defer tree.SetWatcher(nil)
+	tree.OpenAllBranches()
+
+	branch, err := storage.Child(root, "A")
+	assert.NoError(t, err)
+	assert.True(t, tree.IsBranch(branch.String()))
+
+	assert.NoError(t, os.Remove(path.Join(tempDir, "A")))
+	assert.Eventually(t, func() bool {
+		return !tree.IsBranch(branch.String())
+	}, time.Second, time.Millisecond*10)
+	remaining, err := storage.Child(root, "B")
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		children := tree.ChildUIDs(root.String())
+		return len(children) == 1 && children[0] == remaining.String()
+	}, time.Second, time.Millisecond*10)
+}
+
+func TestFileTree_SetWatcher_Unwatch(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	branchA, _ := storage.Child(root, "A")
+	branchB, _ := storage.Child(root, "B")
+	tree.OpenAllBranches()
+	watcher := &recordingWatcher{events: make(chan fyne.URI)}
+	tree.SetWatcher(watcher)
+	assert.ElementsMatch(t, []string{root.String(), branchA.String(), branchB.String()}, watcher.watching())
+
+	tree.CloseBranch(branchB.String())
+	assert.ElementsMatch(t, []string{root.String(), branchA.String()}, watcher.watching())
+	_, listed := tree.childCache[branchB.String()]
+	assert.False(t, listed)
+
+	// a change to the root keeps the listing of its children
+	leaf, _ := storage.Child(branchA, "E.txt")
+	writeFile(t, tempDir, "A/E.txt", "e")
+	watcher.events <- root
+	watcher.events <- branchA
+	assert.Eventually(t, func() bool {
+		tree.cacheLock.Lock()
+		defer tree.cacheLock.Unlock()
+		return len(tree.childCache[branchA.String()]) == 1 && tree.childCache[branchA.String()][0] == leaf.String()
+	}, time.Second, time.Millisecond*10)
+	waitForFileTree(t, tree)
+
+	replacement := &recordingWatcher{events: make(chan fyne.URI)}
+	tree.SetWatcher(replacement)
+	assert.Empty(t, watcher.watching())
+	assert.True(t, watcher.closed)
+	assert.ElementsMatch(t, []string{root.String(), branchA.String()}, replacement.watching())
+
+	test.WidgetRenderer(tree).Destroy()
+	assert.Empty(t, replacement.watching())
+	assert.True(t, replacement.closed)
+}
+
+func TestFileTree_SetWatcher_Filter(t *testing.T) {
+	test.NewApp()
+
+	tempDir := createTempDir(t)
+	defer os.RemoveAll(tempDir)
+
+	root := storage.NewFileURI(tempDir)
+	branch, _ := storage.Child(root, "B")
+	leaf, _ := storage.Child(branch, "C.txt")
+	tree := NewFileTree(root)
+	defer waitForFileTree(t, tree)
+	tree.OpenAllBranches()
+	watcher := &recordingWatcher{events: make(chan fyne.URI)}
+	tree.SetWatcher(watcher)
+	defer test.WidgetRenderer(tree).Destroy()
+
+	tree.Filter = storage.NewExtensionFileFilter([]string{".txt"})
+	tree.FilterLeavesOnly = true
+	writeFile(t, tempDir, "B/E.md", "e")
+	watcher.events <- branch // listed with the filter set since the last load
+	assert.Eventually(t, func() bool {
+		tree.cacheLock.Lock()
+		defer tree.cacheLock.Unlock()
+		children := tree.childCache[branch.String()]
+		return len(children) == 2 && children[0] == leaf.String()
+	}, time.Second, time.Millisecond*10)
+}
+
+type recordingWatcher struct {
+	events  chan fyne.URI
+	closed  bool
+	watched []string
+	lock    sync.Mutex
+}
+
+func (w *recordingWatcher) Watch(u fyne.ListableURI) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.watched = append(w.watched, u.String())
+	return nil
+}
+
+func (w *recordingWatcher) Unwatch(u fyne.URI) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for i, watched := range w.watched {
+		if watched == u.String() {
+			w.watched = append(w.watched[:i], w.watched[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (w *recordingWatcher) Events() <-chan fyne.URI {
+	return w.events
+}
+
+func (w *recordingWatcher) Close() error {
+	w.closed = true
+	return nil
+}
+
+func (w *recordingWatcher) watching() []string {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return append([]string{}, w.watched...)
+}
+
+func testFileWatcher(t *testing.T, w FileWatcher, dir string) {
+	t.Helper()
+	defer w.Close()
+
+	branch := storage.NewFileURI(path.Join(dir, "B"))
+	listable, err := storage.ListerForURI(branch)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Watch(listable))
+
+	assert.NoError(t, ioutil.WriteFile(path.Join(dir, "B", "E.txt"), []byte("e"), os.ModePerm))
+	select {
+	case u := <-w.Events():
+		assert.Equal(t, branch.String(), u.String())
+	case <-time.After(time.Second):
+		t.Error("Timed out waiting for change")
+	}
+
+	assert.NoError(t, w.Unwatch(branch))
+	assert.NoError(t, os.Remove(path.Join(dir, "B", "E.txt")))
+	select {
+	case u := <-w.Events():
+		t.Error("Unexpected change reported for", u)
+	case <-time.After(time.Millisecond * 50):
+	}
+}
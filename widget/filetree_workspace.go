@@ -0,0 +1,158 @@
+package widget
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// workspaceRootID is the ID of the synthetic node containing the roots of a workspace when it is shown.
+// When it is hidden the tree uses an empty root ID, which widget.Tree does not display.
+const workspaceRootID = "\x00workspace"
+
+// NewFileTreeWithRoots creates a new FileTree showing each of the given URIs as a top-level node.
+// The roots are children of a hidden synthetic root, roots can be added or removed while the tree is shown.
+func NewFileTreeWithRoots(roots ...fyne.URI) *FileTree {
+	tree := newFileTree("")
+	tree.rootNames = make(map[widget.TreeNodeID]string)
+	tree.childCache[""] = []widget.TreeNodeID{}
+	for _, u := range roots {
+		tree.AddRoot(u, "")
+	}
+	return tree
+}
+
+// AddRoot adds a top-level node to a tree created by NewFileTreeWithRoots, after the existing roots.
+// The name is shown in place of the name of the URI, unless it is empty.
+// If the URI is already a root only its name is changed.
+func (t *FileTree) AddRoot(u fyne.URI, name string) {
+	if !t.isWorkspace() {
+		fyne.LogError("Roots can only be added to a tree created by NewFileTreeWithRoots", nil)
+		return
+	}
+
+	id := u.String()
+	t.cacheLock.Lock()
+	if _, ok := t.rootNames[id]; !ok {
+		t.childCache[t.Root] = append(append([]widget.TreeNodeID{}, t.childCache[t.Root]...), id)
+	}
+	t.rootNames[id] = name
+	t.uriCache.put(id, u)
+	t.cacheLock.Unlock()
+	t.Refresh()
+}
+
+// RemoveRoot removes a top-level node that was added to a tree created by NewFileTreeWithRoots.
+// Any selected nodes inside it are unselected.
+func (t *FileTree) RemoveRoot(u fyne.URI) {
+	if !t.isWorkspace() {
+		return
+	}
+
+	id := t.branchID(u)
+	t.cacheLock.Lock()
+	if _, ok := t.rootNames[id]; !ok {
+		t.cacheLock.Unlock()
+		return
+	}
+	delete(t.rootNames, id)
+	var roots []widget.TreeNodeID
+	for _, root := range t.childCache[t.Root] {
+		if root != id {
+			roots = append(roots, root)
+		}
+	}
+	t.childCache[t.Root] = roots
+	t.cacheLock.Unlock()
+
+	t.Tree.CloseBranch(id)
+	t.invalidate(id)
+	t.forgetOpen(id)
+	t.unselectRemoved(u)
+	t.Refresh()
+}
+
+// Roots returns the URIs of the top-level nodes of a tree created by NewFileTreeWithRoots, in order.
+// For other trees it returns the root URI.
+func (t *FileTree) Roots() []fyne.URI {
+	if !t.isWorkspace() {
+		u, err := t.toURI(t.Root)
+		if err != nil {
+			return nil
+		}
+		return []fyne.URI{u}
+	}
+
+	t.cacheLock.Lock()
+	ids := t.childCache[t.Root]
+	t.cacheLock.Unlock()
+	uris := make([]fyne.URI, 0, len(ids))
+	for _, id := range ids {
+		if u, err := t.toURI(id); err == nil {
+			uris = append(uris, u)
+		}
+	}
+	return uris
+}
+
+// SetWorkspaceRoot shows a top-level node with the given name that contains all roots of a tree
+// created by NewFileTreeWithRoots. An empty name hides it again, showing the roots at the top level.
+func (t *FileTree) SetWorkspaceRoot(name string) {
+	if !t.isWorkspace() {
+		return
+	}
+
+	id := ""
+	if name != "" {
+		id = workspaceRootID
+	}
+	t.cacheLock.Lock()
+	t.workspaceName = name
+	if id != t.Root {
+		t.childCache[id] = t.childCache[t.Root]
+		delete(t.childCache, t.Root)
+		t.Root = id
+	}
+	t.cacheLock.Unlock()
+	t.Refresh()
+}
+
+func (t *FileTree) isWorkspace() bool {
+	t.cacheLock.Lock()
+	defer t.cacheLock.Unlock()
+	return t.rootNames != nil
+}
+
+// isRoot returns true if the node is the root of the tree or one of the roots of a workspace.
+func (t *FileTree) isRoot(id widget.TreeNodeID) bool {
+	if id == t.Root {
+		return true
+	}
+	t.cacheLock.Lock()
+	defer t.cacheLock.Unlock()
+	_, ok := t.rootNames[id]
+	return ok
+}
+
+// isWorkspaceRoot returns true for the synthetic node that contains the roots of a workspace.
+func (t *FileTree) isWorkspaceRoot(id widget.TreeNodeID) bool {
+	return id == workspaceRootID || (id == "" && t.isWorkspace())
+}
+
+// rootID returns the ID of the root that a URI represents, if it differs from the URI by a trailing slash.
+func (t *FileTree) rootID(id string) (widget.TreeNodeID, bool) {
+	trimmed := strings.TrimSuffix(id, "/")
+	if trimmed == strings.TrimSuffix(t.Root, "/") {
+		return t.Root, true
+	}
+
+	t.cacheLock.Lock()
+	defer t.cacheLock.Unlock()
+	for root := range t.rootNames {
+		if trimmed == strings.TrimSuffix(root, "/") {
+			return root, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,62 @@
+package widget
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestFileTree_Workspace(t *testing.T) {
+	test.NewApp()
+
+	tempDir1 := createTempDir(t)
+	defer os.RemoveAll(tempDir1)
+	tempDir2 := createTempDir(t)
+	defer os.RemoveAll(tempDir2)
+
+	root1 := storage.NewFileURI(tempDir1)
+	root2 := storage.NewFileURI(tempDir2)
+	tree := NewFileTreeWithRoots(root1)
+	defer waitForFileTree(t, tree)
+	tree.AddRoot(root2, "Second")
+	tree.OpenAllBranches()
+	window := test.NewWindow(tree)
+	defer window.Close()
+	waitForFileTree(t, tree)
+	window.Resize(fyne.NewSize(300, 600))
+	waitForFileTree(t, tree)
+
+	assert.Equal(t, []fyne.URI{root1, root2}, tree.Roots())
+	assert.Nil(t, findFileTreeNode(tree, ""))
+	assert.Equal(t, root1.Name(), findFileTreeNode(tree, root1.String()).label.Text)
+	assert.Equal(t, "Second", findFileTreeNode(tree, root2.String()).label.Text)
+	slashed, _ := storage.ParseURI(root2.String() + "/")
+	assert.Equal(t, root2.String(), tree.branchID(slashed))
+
+	tree.SetSorter(SortDescending(SortByName))
+	assert.Equal(t, []fyne.URI{root1, root2}, tree.Roots())
+
+	branch, _ := storage.Child(root1, "B")
+	leaf, _ := storage.Child(branch, "C.txt")
+	tree.SetSelectedURIs([]fyne.URI{leaf})
+	tree.SelectAll()
+	assert.NotContains(t, tree.SelectedURIs(), nil)
+	tree.RemoveRoot(root1)
+	assert.Equal(t, []fyne.URI{root2}, tree.Roots())
+	assert.Nil(t, findFileTreeNode(tree, root1.String()))
+	for _, u := range tree.SelectedURIs() {
+		assert.NotContains(t, u.String(), tempDir1)
+	}
+
+	tree.SetWorkspaceRoot("Workspace")
+	assert.Equal(t, "Workspace", findFileTreeNode(tree, workspaceRootID).label.Text)
+	assert.Equal(t, []fyne.URI{root2}, tree.Roots())
+	tree.SetWorkspaceRoot("")
+	assert.Nil(t, findFileTreeNode(tree, workspaceRootID))
+	assert.NotNil(t, findFileTreeNode(tree, root2.String()))
+}
@@ -4,7 +4,6 @@ import (
 	"image"
 	"image/draw"
 	"image/gif"
-	"sync"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -16,13 +15,14 @@ import (
 // AnimatedGif widget shows a Gif image with many frames.
 type AnimatedGif struct {
 	widget.BaseWidget
-	min fyne.Size
+	min       fyne.Size
+	fill      AnimationFill
+	intrinsic bool
 
-	src               *gif.GIF
-	dst               *canvas.Image
-	remaining         int
-	stopping, running bool
-	runLock           sync.RWMutex
+	animationPlayer
+	src    *gif.GIF
+	dst    *canvas.Image
+	screen *image.NRGBA
 }
 
 // NewAnimatedGif creates a new widget loaded to show the specified image.
@@ -42,7 +42,7 @@ func NewAnimatedGif(u fyne.URI) (*AnimatedGif, error) {
 
 // CreateRenderer loads the widget renderer for this widget. This is an internal requirement for Fyne.
 func (g *AnimatedGif) CreateRenderer() fyne.WidgetRenderer {
-	return &gifRenderer{gif: g}
+	return &animationRenderer{widget: g, dst: g.dst}
 }
 
 // Load is used to change the gif file shown.
@@ -60,17 +60,45 @@ func (g *AnimatedGif) Load(u fyne.URI) error {
 		return err
 	}
 	g.src = pix
-	g.dst.Image = pix.Image[0]
-	g.dst.Refresh()
+
+	// frames may be smaller than the logical screen, so we compose them onto a buffer of the full size
+	bounds := image.Rect(0, 0, pix.Config.Width, pix.Config.Height)
+	if bounds.Empty() {
+		for _, frame := range pix.Image {
+			bounds = bounds.Union(frame.Bounds())
+		}
+	}
+	g.screen = image.NewNRGBA(bounds)
+	g.drawFrame(0)
+	g.layoutFrame()
 
 	return nil
 }
 
 // MinSize returns the minimum size that this GIF can occupy.
 // Because gif images are measured in pixels we cannot use the dimensions, so this defaults to 0x0.
-// You can set a minimum size if required using SetMinSize.
+// You can set a minimum size if required using SetMinSize, or use the size of the image with SetIntrinsicSize.
 func (g *AnimatedGif) MinSize() fyne.Size {
-	return g.min
+	if !g.intrinsic || g.screen == nil {
+		return g.min
+	}
+
+	return pixelSize(g, g.screen.Bounds().Size()).Max(g.min)
+}
+
+// SetFillMode changes how the frames are scaled into the space available to this AnimatedGif.
+// The default is AnimationFillContain.
+func (g *AnimatedGif) SetFillMode(fill AnimationFill) {
+	g.fill = fill
+	g.layoutFrame()
+}
+
+// SetIntrinsicSize sets whether the minimum size should be the logical screen size of the gif.
+// The pixel size is scaled by the canvas scale so the image is not shrunk on the output device.
+// A minimum size set using SetMinSize will still be respected if it is larger.
+func (g *AnimatedGif) SetIntrinsicSize(intrinsic bool) {
+	g.intrinsic = intrinsic
+	g.Refresh()
 }
 
 // SetMinSize sets the smallest possible size that this AnimatedGif should be drawn at.
@@ -80,87 +108,65 @@ func (g *AnimatedGif) SetMinSize(min fyne.Size) {
 }
 
 // Start begins the animation. The speed of the transition is controlled by the loaded gif file.
+// The animation will pause, showing a static frame, while the CurrentMotionPolicy does not allow it to play.
 func (g *AnimatedGif) Start() {
-	if g.isRunning() {
+	if g.src == nil {
 		return
 	}
-	g.runLock.Lock()
-	g.running = true
-	g.runLock.Unlock()
-
-	buffer := image.NewNRGBA(g.dst.Image.Bounds())
-	draw.Draw(buffer, g.dst.Image.Bounds(), g.src.Image[0], image.Point{}, draw.Over)
-	g.dst.Image = buffer
-	g.dst.Refresh()
-
-	go func() {
-		switch g.src.LoopCount {
-		case -1: // don't loop
-			g.remaining = 1
-		case 0: // loop forever
-			g.remaining = -1
-		default:
-			g.remaining = g.src.LoopCount + 1
-		}
 
-		for g.remaining != 0 {
-			for c, srcImg := range g.src.Image {
-				if g.isStopping() {
-					break
-				}
-				draw.Draw(buffer, g.dst.Image.Bounds(), srcImg, image.Point{}, draw.Over)
-				g.dst.Refresh()
-
-				time.Sleep(time.Millisecond * time.Duration(g.src.Delay[c]) * 10)
-			}
-			if g.remaining > -1 { // don't underflow int
-				g.remaining--
-			}
+	var previous *image.NRGBA
+	g.play(g, loopsForCount(g.src.LoopCount), len(g.src.Image), func(c int) time.Duration {
+		if c == 0 {
+			draw.Draw(g.screen, g.screen.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		} else {
+			g.disposeFrame(c-1, previous)
 		}
+		previous = g.drawFrame(c)
+		g.dst.Refresh()
 
-		g.running = false
-	}()
+		return time.Millisecond * time.Duration(g.src.Delay[c]) * 10
+	})
 }
 
 // Stop will request that the animation stops running, the last frame will remain visible
 func (g *AnimatedGif) Stop() {
-	g.runLock.Lock()
-	g.stopping = true
-	g.runLock.Unlock()
+	g.stop()
 }
 
-func (g *AnimatedGif) isStopping() bool {
-	g.runLock.RLock()
-	defer g.runLock.RUnlock()
-	return g.stopping
-}
-
-func (g *AnimatedGif) isRunning() bool {
-	g.runLock.RLock()
-	defer g.runLock.RUnlock()
-	return g.running
-}
-
-type gifRenderer struct {
-	gif *AnimatedGif
-}
-
-func (g *gifRenderer) Destroy() {
-	g.gif.Stop()
-}
+// disposeFrame prepares the screen for the next frame as requested by the disposal method of the frame at index.
+func (g *AnimatedGif) disposeFrame(index int, previous *image.NRGBA) {
+	if index >= len(g.src.Disposal) {
+		return
+	}
 
-func (g *gifRenderer) Layout(size fyne.Size) {
-	g.gif.dst.Resize(size)
+	switch g.src.Disposal[index] {
+	case gif.DisposalBackground:
+		bounds := g.src.Image[index].Bounds()
+		draw.Draw(g.screen, bounds, image.Transparent, image.Point{}, draw.Src)
+	case gif.DisposalPrevious:
+		if previous != nil {
+			draw.Draw(g.screen, g.screen.Bounds(), previous, previous.Bounds().Min, draw.Src)
+		}
+	}
 }
 
-func (g *gifRenderer) MinSize() fyne.Size {
-	return g.gif.MinSize()
-}
+// drawFrame composes the frame at index onto the screen at its offset within the logical screen.
+// If the frame should be disposed by restoring the previous content a copy of that is returned.
+func (g *AnimatedGif) drawFrame(index int) (previous *image.NRGBA) {
+	if index < len(g.src.Disposal) && g.src.Disposal[index] == gif.DisposalPrevious {
+		previous = image.NewNRGBA(g.screen.Bounds())
+		draw.Draw(previous, previous.Bounds(), g.screen, g.screen.Bounds().Min, draw.Src)
+	}
 
-func (g *gifRenderer) Objects() []fyne.CanvasObject {
-	return []fyne.CanvasObject{g.gif.dst}
+	frame := g.src.Image[index]
+	draw.Draw(g.screen, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+	return previous
 }
 
-func (g *gifRenderer) Refresh() {
-	g.gif.dst.Refresh()
+func (g *AnimatedGif) layoutFrame() {
+	var frame image.Image
+	if g.screen != nil {
+		frame = g.screen
+	}
+	layoutAnimationFrame(g, g.dst, frame, g.fill, g.Size())
 }
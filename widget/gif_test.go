@@ -1,12 +1,20 @@
 package widget
 
 import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io/ioutil"
+	"os"
+	"path"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/test"
 )
@@ -35,3 +43,71 @@ func TestAnimatedGif_MinSize(t *testing.T) {
 	assert.Equal(t, float32(10), gif.MinSize().Width)
 	assert.Equal(t, float32(10), gif.MinSize().Height)
 }
+
+func TestAnimatedGif_FillMode(t *testing.T) {
+	test.NewApp()
+	gif, err := NewAnimatedGif(storage.NewFileURI("./testdata/gif/earth.gif"))
+	assert.Nil(t, err)
+	gif.Resize(fyne.NewSize(100, 50))
+
+	gif.SetFillMode(AnimationFillCover)
+	assert.Equal(t, canvas.ImageFillStretch, gif.dst.FillMode)
+	b := gif.dst.Image.Bounds()
+	assert.Equal(t, 2*b.Dy(), b.Dx())
+
+	gif.SetFillMode(AnimationFillOriginal)
+	assert.Equal(t, gif.screen, gif.dst.Image)
+	assert.Equal(t, float32(gif.screen.Bounds().Dx()), gif.dst.Size().Width)
+
+	gif.SetFillMode(AnimationFillContain)
+	assert.Equal(t, canvas.ImageFillContain, gif.dst.FillMode)
+	assert.Equal(t, fyne.NewSize(100, 50), gif.dst.Size())
+}
+
+func TestAnimatedGif_IntrinsicSize(t *testing.T) {
+	test.NewApp()
+	gif, _ := NewAnimatedGif(storage.NewFileURI("./testdata/gif/earth.gif"))
+	gif.SetIntrinsicSize(true)
+	b := gif.screen.Bounds()
+	assert.Equal(t, fyne.NewSize(float32(b.Dx()), float32(b.Dy())), gif.MinSize())
+
+	gif.SetMinSize(fyne.NewSize(1000, 10))
+	assert.Equal(t, fyne.NewSize(1000, float32(b.Dy())), gif.MinSize())
+}
+
+func TestAnimatedGif_SmallFrames(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "gif")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	palette := color.Palette{color.Transparent, color.Black, color.White}
+	first := image.NewPaletted(image.Rect(0, 0, 8, 8), palette)
+	draw.Draw(first, first.Bounds(), image.Black, image.Point{}, draw.Src)
+	second := image.NewPaletted(image.Rect(4, 4, 6, 6), palette)
+	draw.Draw(second, second.Bounds(), image.White, image.Point{}, draw.Src)
+
+	file := path.Join(tempDir, "small.gif")
+	out, err := os.Create(file)
+	assert.NoError(t, err)
+	err = gif.EncodeAll(out, &gif.GIF{
+		Image:    []*image.Paletted{first, second},
+		Delay:    []int{1, 1},
+		Disposal: []byte{gif.DisposalNone, gif.DisposalNone},
+		Config:   image.Config{ColorModel: palette, Width: 8, Height: 8},
+	})
+	assert.NoError(t, err)
+	out.Close()
+
+	anim, err := NewAnimatedGif(storage.NewFileURI(file))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 8, 8), anim.screen.Bounds())
+
+	anim.drawFrame(1)
+	assert.Equal(t, color.NRGBA{A: 0xff}, anim.screen.At(1, 1))
+	assert.Equal(t, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}, anim.screen.At(5, 5))
+
+	anim.src.Disposal[1] = gif.DisposalBackground
+	anim.disposeFrame(1, nil)
+	assert.Equal(t, color.NRGBA{}, anim.screen.At(5, 5))
+	assert.Equal(t, color.NRGBA{A: 0xff}, anim.screen.At(1, 1))
+}
@@ -0,0 +1,281 @@
+package widget
+
+import (
+	"image/color"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// GitStatus is the state of a file in a git working tree, compared to the index and the last commit.
+type GitStatus int
+
+const (
+	// GitStatusUnmodified is a tracked file that has not changed since the last commit.
+	GitStatusUnmodified GitStatus = iota
+	// GitStatusIgnored is a file that is not tracked and matches an ignore rule.
+	GitStatusIgnored
+	// GitStatusUntracked is a new file that has not been added to the index.
+	GitStatusUntracked
+	// GitStatusAdded is a new file that has been added to the index.
+	GitStatusAdded
+	// GitStatusModified is a tracked file that has changed, whether or not the change is staged.
+	GitStatusModified
+	// GitStatusDeleted is a tracked file that has been removed.
+	// Folders are shown with this status if it is the most important of their contents.
+	GitStatusDeleted
+	// GitStatusConflicted is a file with unresolved merge conflicts.
+	GitStatusConflicted
+)
+
+var (
+	gitAddedColor      = color.NRGBA{R: 0x58, G: 0xb3, B: 0x6e, A: 0xff}
+	gitConflictedColor = color.NRGBA{R: 0xb6, G: 0x5c, B: 0xd6, A: 0xff}
+	gitDeletedColor    = color.NRGBA{R: 0xe0, G: 0x4f, B: 0x4a, A: 0xff}
+	gitModifiedColor   = color.NRGBA{R: 0xd6, G: 0x9a, B: 0x3c, A: 0xff}
+)
+
+// GitDecorator is a FileDecorator that marks files by their status in a local git repository,
+// such as "M" for modified files. Folders are coloured if they contain changes.
+// The status is read when the decorator is created, Refresh updates it after files change.
+type GitDecorator struct {
+	repo *gitRepository
+
+	lock  sync.RWMutex
+	files map[string]GitStatus
+	dirs  map[string]GitStatus
+}
+
+// NewGitDecorator returns a decorator for the git repository containing the given folder.
+// An error is returned if the folder is not a local file or not in a git working tree.
+func NewGitDecorator(dir fyne.URI) (*GitDecorator, error) {
+	if dir.Scheme() != "file" {
+		return nil, errGitNotFound
+	}
+	repo, err := findGitRepository(dir.Path())
+	if err != nil {
+		return nil, err
+	}
+
+	g := &GitDecorator{repo: repo}
+	return g, g.Refresh()
+}
+
+// Decorate returns the decoration for the status of a URI.
+//
+// Implements: FileDecorator
+func (g *GitDecorator) Decorate(u fyne.URI) *FileDecoration {
+	rel, ok := g.relativePath(u)
+	if !ok {
+		return nil
+	}
+
+	g.lock.RLock()
+	status, file := g.files[rel]
+	if !file {
+		status = g.dirs[rel]
+	}
+	g.lock.RUnlock()
+
+	d := gitDecoration(status)
+	if d != nil && !file && status != GitStatusIgnored {
+		d.Badge = "•"
+	}
+	return d
+}
+
+// Refresh reads the status of every file in the working tree again.
+func (g *GitDecorator) Refresh() error {
+	files, err := g.repo.status()
+	if err != nil {
+		return err
+	}
+
+	dirs := make(map[string]GitStatus)
+	for file, status := range files {
+		if status == GitStatusIgnored {
+			continue
+		}
+		for dir := path.Dir(file); dir != "."; dir = path.Dir(dir) {
+			if status > dirs[dir] {
+				dirs[dir] = status
+			}
+		}
+	}
+
+	g.lock.Lock()
+	g.files, g.dirs = files, dirs
+	g.lock.Unlock()
+	return nil
+}
+
+// Status returns the git status of a URI, for a folder this is the most important status of its contents.
+func (g *GitDecorator) Status(u fyne.URI) GitStatus {
+	rel, ok := g.relativePath(u)
+	if !ok {
+		return GitStatusUnmodified
+	}
+
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	if status, ok := g.files[rel]; ok {
+		return status
+	}
+	return g.dirs[rel]
+}
+
+func (g *GitDecorator) relativePath(u fyne.URI) (string, bool) {
+	if u.Scheme() != "file" {
+		return "", false
+	}
+	rel, err := filepath.Rel(g.repo.workDir, filepath.FromSlash(u.Path()))
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+func gitDecoration(status GitStatus) *FileDecoration {
+	switch status {
+	case GitStatusIgnored:
+		return &FileDecoration{TextColor: theme.DisabledTextColor()}
+	case GitStatusUntracked:
+		return &FileDecoration{Badge: "U", BadgeColor: gitAddedColor, TextColor: gitAddedColor}
+	case GitStatusAdded:
+		return &FileDecoration{Badge: "A", BadgeColor: gitAddedColor, TextColor: gitAddedColor}
+	case GitStatusModified:
+		return &FileDecoration{Badge: "M", BadgeColor: gitModifiedColor, TextColor: gitModifiedColor}
+	case GitStatusDeleted:
+		return &FileDecoration{Badge: "D", BadgeColor: gitDeletedColor, TextColor: gitDeletedColor}
+	case GitStatusConflicted:
+		return &FileDecoration{Badge: "C", BadgeColor: gitConflictedColor, TextColor: gitConflictedColor}
+	}
+	return nil
+}
+
+// status compares the working tree to the index and the index to HEAD, returning the status of each path
+// that is not unmodified. Ignored folders are reported without their contents.
+func (r *gitRepository) status() (map[string]GitStatus, error) {
+	index, err := r.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	head, err := r.headFiles()
+	if err != nil {
+		return nil, err
+	}
+	var indexTime int64
+	if info, err := os.Stat(filepath.Join(r.gitDir, "index")); err == nil {
+		indexTime = info.ModTime().UnixNano()
+	}
+
+	trackedDirs := make(map[string]bool)
+	for file := range index {
+		for dir := path.Dir(file); dir != "."; dir = path.Dir(dir) {
+			trackedDirs[dir] = true
+		}
+	}
+	ignore := newGitIgnore(r.workDir, filepath.Join(r.gitDir, "info", "exclude"))
+	files := make(map[string]GitStatus)
+	seen := make(map[string]bool)
+
+	err = filepath.Walk(r.workDir, func(file string, info os.FileInfo, err error) error {
+		if err != nil || file == r.workDir {
+			return nil
+		}
+		rel, err := filepath.Rel(r.workDir, file)
+		if err != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			if !trackedDirs[rel] && ignore.Ignored(rel, true) {
+				files[rel] = GitStatusIgnored
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(file, ".git")); err == nil && !trackedDirs[rel] {
+				if _, ok := index[rel]; !ok { // a nested repository that is not a submodule
+					files[rel] = GitStatusUntracked
+				}
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		entry, tracked := index[rel]
+		if !tracked {
+			if ignore.Ignored(rel, false) {
+				files[rel] = GitStatusIgnored
+			} else {
+				files[rel] = GitStatusUntracked
+			}
+			return nil
+		}
+
+		seen[rel] = true
+		committed, inHead := head[rel]
+		switch {
+		case entry.stage > 0:
+			files[rel] = GitStatusConflicted
+		case !entry.assumed && fileChanged(file, info, entry, indexTime):
+			files[rel] = GitStatusModified
+		case !inHead:
+			files[rel] = GitStatusAdded
+		case committed != entry.hash:
+			files[rel] = GitStatusModified
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for file := range index {
+		if !seen[file] && index[file].mode&0170000 != 0160000 {
+			files[file] = GitStatusDeleted
+		}
+	}
+	for file := range head {
+		if _, ok := index[file]; !ok {
+			files[file] = GitStatusDeleted
+		}
+	}
+	return files, nil
+}
+
+// fileChanged checks if a working tree file differs from its index entry.
+// The content is only compared if the size or modification time differ, or the file was changed
+// so soon after the index was written that the time cannot be trusted.
+func fileChanged(file string, info os.FileInfo, entry gitIndexEntry, indexTime int64) bool {
+	if entry.mode&0170000 == 0160000 { // submodules are reported by their own repository
+		return false
+	}
+	if info.Mode()&os.ModeSymlink == 0 && uint32(info.Size()) != entry.size {
+		return true
+	}
+	modified := info.ModTime().UnixNano()
+	if modified == entry.mtime && modified < indexTime {
+		return false
+	}
+
+	var content []byte
+	var err error
+	if info.Mode()&os.ModeSymlink != 0 {
+		var target string
+		target, err = os.Readlink(file)
+		content = []byte(filepath.ToSlash(target))
+	} else {
+		content, err = ioutil.ReadFile(file)
+	}
+	return err != nil || hashBlob(content) != entry.hash
+}
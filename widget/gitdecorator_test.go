@@ -0,0 +1,168 @@
+package widget
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestGitDecorator(t *testing.T) {
+	repo := createGitRepo(t)
+	defer os.RemoveAll(repo)
+
+	writeFile(t, repo, "modified.txt", "changed")
+	writeFile(t, repo, "src/new.go", "package src")
+	writeFile(t, repo, "staged.txt", "staged")
+	writeFile(t, repo, "build/out.bin", "binary")
+	writeFile(t, repo, "debug.log", "log")
+	runGit(t, repo, "add", "staged.txt")
+	assert.NoError(t, os.Remove(filepath.Join(repo, "deleted.txt")))
+
+	g, err := NewGitDecorator(storage.NewFileURI(repo))
+	assert.NoError(t, err)
+	status := func(name string) GitStatus {
+		return g.Status(storage.NewFileURI(filepath.Join(repo, name)))
+	}
+	assert.Equal(t, GitStatusUnmodified, status("unchanged.txt"))
+	assert.Equal(t, GitStatusUnmodified, status("src/keep.go"))
+	assert.Equal(t, GitStatusModified, status("modified.txt"))
+	assert.Equal(t, GitStatusUntracked, status("src/new.go"))
+	assert.Equal(t, GitStatusUntracked, status("src"))
+	assert.Equal(t, GitStatusAdded, status("staged.txt"))
+	assert.Equal(t, GitStatusDeleted, status("deleted.txt"))
+	assert.Equal(t, GitStatusIgnored, status("build"))
+	assert.Equal(t, GitStatusIgnored, status("debug.log"))
+
+	assert.Nil(t, g.Decorate(storage.NewFileURI(filepath.Join(repo, "unchanged.txt"))))
+	d := g.Decorate(storage.NewFileURI(filepath.Join(repo, "modified.txt")))
+	assert.Equal(t, "M", d.Badge)
+	assert.Equal(t, gitModifiedColor, d.TextColor)
+	assert.Equal(t, "•", g.Decorate(storage.NewFileURI(filepath.Join(repo, "src"))).Badge)
+
+	runGit(t, repo, "add", "-A")
+	runGit(t, repo, "commit", "-q", "-m", "second")
+	assert.NoError(t, g.Refresh())
+	assert.Equal(t, GitStatusUnmodified, status("modified.txt"))
+	assert.Equal(t, GitStatusUnmodified, status("src"))
+	assert.Equal(t, GitStatusIgnored, status("debug.log"))
+}
+
+func TestGitDecorator_PackedIndexV4(t *testing.T) {
+	repo := createGitRepo(t)
+	defer os.RemoveAll(repo)
+
+	writeFile(t, repo, "unchanged.txt", "unchanged\nwith another line\n")
+	runGit(t, repo, "commit", "-q", "-a", "-m", "second")
+	runGit(t, repo, "gc", "-q", "--aggressive")
+	runGit(t, repo, "update-index", "--index-version", "4")
+	writeFile(t, repo, "modified.txt", "changed")
+
+	g, err := NewGitDecorator(storage.NewFileURI(filepath.Join(repo, "src")))
+	assert.NoError(t, err)
+	status := func(name string) GitStatus {
+		return g.Status(storage.NewFileURI(filepath.Join(repo, name)))
+	}
+	assert.Equal(t, GitStatusUnmodified, status("unchanged.txt"))
+	assert.Equal(t, GitStatusModified, status("modified.txt"))
+}
+
+func TestFileTree_Decorator(t *testing.T) {
+	test.NewApp()
+
+	repo := createGitRepo(t)
+	defer os.RemoveAll(repo)
+	writeFile(t, repo, "modified.txt", "changed")
+
+	root := storage.NewFileURI(repo)
+	tree := NewFileTree(root)
+	g, err := NewGitDecorator(root)
+	assert.NoError(t, err)
+	tree.Decorator = g
+	tree.listAll(tree.Root)
+	tree.OpenBranch(tree.Root)
+	window := test.NewWindow(tree)
+	defer window.Close()
+	window.Resize(fyne.NewSize(300, 300))
+
+	modified, _ := storage.Child(root, "modified.txt")
+	node := findFileTreeNode(tree, modified.String())
+	assert.True(t, node.badge.Visible())
+	assert.Equal(t, "M", node.badge.Text)
+	assert.True(t, node.name.Visible())
+	assert.False(t, node.label.Visible())
+	assert.Equal(t, "modified.txt", node.name.Text)
+
+	unchanged, _ := storage.Child(root, "unchanged.txt")
+	node = findFileTreeNode(tree, unchanged.String())
+	assert.False(t, node.decorations.Visible())
+	assert.True(t, node.label.Visible())
+}
+
+// createGitRepo creates a repository with a committed file of each kind, skipping the test if git is not installed.
+func TestGitPack_Truncated(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fyne-x-pack-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for name, data := range map[string][]byte{
+		"size":       {0x95},                               // the size continues past the end of the file
+		"huge":       {0xb0, 0xff, 0xff, 0xff, 0xff, 0x7f}, // a blob larger than maxGitObjectSize
+		"no offset":  {0x65},                               // an offset delta without its base offset
+		"zero":       {0x65, 0x00},                         // an offset delta referring to itself
+		"before":     {0x65, 0x01},                         // an offset delta before the start of the pack
+		"continuing": {0x65, 0x80},                         // the base offset continues past the end of the file
+	} {
+		file := filepath.Join(dir, "pack")
+		assert.NoError(t, ioutil.WriteFile(file, data, 0644))
+		_, _, err := (&gitPack{file: file}).read(&gitRepository{}, 0)
+		assert.Equal(t, errGitObject, err, name)
+	}
+}
+
+func createGitRepo(t *testing.T) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	repo, err := ioutil.TempDir("", "fyne-x-git-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runGit(t, repo, "init", "-q")
+	writeFile(t, repo, ".gitignore", "build/\n*.log\n")
+	writeFile(t, repo, "unchanged.txt", "unchanged")
+	writeFile(t, repo, "modified.txt", "original")
+	writeFile(t, repo, "deleted.txt", "deleted")
+	writeFile(t, repo, "src/keep.go", "package src")
+	runGit(t, repo, "add", "-A")
+	runGit(t, repo, "commit", "-q", "-m", "initial")
+	return repo
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	cmd := exec.Command("git", append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	file := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
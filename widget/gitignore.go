@@ -0,0 +1,191 @@
+package widget
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// gitIgnore matches paths in a working tree against the .gitignore files it contains
+// and the repository exclude file. Paths are slash separated and relative to the root.
+type gitIgnore struct {
+	root string
+
+	lock  sync.Mutex
+	rules map[string][]ignoreRule // the rules loaded from the .gitignore of each directory
+}
+
+type ignoreRule struct {
+	pattern *regexp.Regexp
+	negate  bool
+	dirOnly bool
+	base    bool // the pattern has no slash so it is matched against the name at any depth
+}
+
+func newGitIgnore(root string, excludes ...string) *gitIgnore {
+	g := &gitIgnore{root: root, rules: make(map[string][]ignoreRule)}
+	var rules []ignoreRule
+	for _, file := range excludes {
+		rules = append(rules, readIgnoreFile(file)...)
+	}
+	g.rules["\x00"] = rules
+	return g
+}
+
+// Ignored returns true if the path, or any directory containing it, is ignored.
+func (g *gitIgnore) Ignored(rel string, dir bool) bool {
+	rel = strings.Trim(rel, "/")
+	if rel == "" || rel == "." {
+		return false
+	}
+
+	parts := strings.Split(rel, "/")
+	for i := 1; i < len(parts); i++ {
+		if g.match(strings.Join(parts[:i], "/"), true) {
+			return true
+		}
+	}
+	return g.match(rel, dir)
+}
+
+// match checks a single path against the rules that apply to it, without checking its parents.
+// The rules from deeper .gitignore files and later lines take priority.
+func (g *gitIgnore) match(rel string, dir bool) bool {
+	ignored := g.matchRules(g.dirRules("\x00"), rel, dir, false)
+	dirs := strings.Split(rel, "/")
+	for i := 0; i < len(dirs); i++ {
+		parent := strings.Join(dirs[:i], "/")
+		within := rel
+		if parent != "" {
+			within = rel[len(parent)+1:]
+		}
+		ignored = g.matchRules(g.dirRules(parent), within, dir, ignored)
+	}
+	return ignored
+}
+
+func (g *gitIgnore) matchRules(rules []ignoreRule, rel string, dir, ignored bool) bool {
+	name := path.Base(rel)
+	for _, r := range rules {
+		if r.dirOnly && !dir {
+			continue
+		}
+		subject := rel
+		if r.base {
+			subject = name
+		}
+		if r.pattern.MatchString(subject) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+// dirRules returns the rules from the .gitignore file in a directory, loading them the first time.
+func (g *gitIgnore) dirRules(dir string) []ignoreRule {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if rules, ok := g.rules[dir]; ok {
+		return rules
+	}
+
+	rules := readIgnoreFile(filepath.Join(g.root, filepath.FromSlash(dir), ".gitignore"))
+	g.rules[dir] = rules
+	return rules
+}
+
+func readIgnoreFile(file string) (rules []ignoreRule) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if r, ok := parseIgnoreRule(scanner.Text()); ok {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+func parseIgnoreRule(line string) (r ignoreRule, ok bool) {
+	if !strings.HasSuffix(line, "\\ ") {
+		line = strings.TrimRight(line, " \t")
+	}
+	if line == "" || line[0] == '#' {
+		return r, false
+	}
+	if line[0] == '!' {
+		r.negate = true
+		line = line[1:]
+	} else if line[0] == '\\' {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		r.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+	if !strings.Contains(line, "/") {
+		r.base = true
+	}
+	line = strings.TrimPrefix(line, "/")
+	if line == "" {
+		return r, false
+	}
+
+	pattern, err := regexp.Compile("^" + globToRegexp(line) + "$")
+	if err != nil {
+		return r, false
+	}
+	r.pattern = pattern
+	return r, true
+}
+
+// globToRegexp converts a gitignore style glob to a regular expression.
+// A * or ? does not match a slash, but ** matches any number of directories.
+func globToRegexp(glob string) string {
+	var re strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if strings.HasPrefix(glob[i:], "**/") {
+				re.WriteString("(?:.*/)?")
+				i += 2
+			} else if strings.HasPrefix(glob[i:], "**") {
+				re.WriteString(".*")
+				i++
+			} else {
+				re.WriteString("[^/]*")
+			}
+		case '?':
+			re.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end == -1 {
+				re.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			re.WriteString("[" + strings.Replace(class, `\`, `\\`, -1) + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				re.WriteString(regexp.QuoteMeta(string(glob[i])))
+			}
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return re.String()
+}
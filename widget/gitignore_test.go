@@ -0,0 +1,40 @@
+package widget
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitIgnore(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	writeFile(t, dir, ".gitignore", "# comment\n*.o\n/root.txt\nbuild/\ndocs/**/*.html\n!keep.o\n")
+	writeFile(t, dir, "B/.gitignore", "*.txt\n!D.txt\n")
+	writeFile(t, dir, "exclude", "secret\n")
+	ignore := newGitIgnore(dir, dir+"/exclude")
+
+	assert.True(t, ignore.Ignored("main.o", false))
+	assert.True(t, ignore.Ignored("A/main.o", false))
+	assert.False(t, ignore.Ignored("keep.o", false))
+	assert.True(t, ignore.Ignored("root.txt", false))
+	assert.False(t, ignore.Ignored("A/root.txt", false))
+	assert.True(t, ignore.Ignored("build", true))
+	assert.False(t, ignore.Ignored("build", false))
+	assert.True(t, ignore.Ignored("build/main.go", false))
+	assert.True(t, ignore.Ignored("docs/a/b/index.html", false))
+	assert.True(t, ignore.Ignored("docs/index.html", false))
+	assert.False(t, ignore.Ignored("index.html", false))
+	assert.True(t, ignore.Ignored("B/C.txt", false))
+	assert.False(t, ignore.Ignored("B/D.txt", false))
+	assert.True(t, ignore.Ignored("A/secret", true))
+}
+
+func TestGlobToRegexp(t *testing.T) {
+	assert.Equal(t, `[^/]*\.go`, globToRegexp("*.go"))
+	assert.Equal(t, `(?:.*/)?a/.*`, globToRegexp("**/a/**"))
+	assert.Equal(t, `file[^/]\[`, globToRegexp("file?["))
+	assert.Equal(t, `[^abc]\*`, globToRegexp(`[!abc]\*`))
+}
@@ -0,0 +1,527 @@
+package widget
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var (
+	errGitNotFound = errors.New("no git repository found")
+	errGitIndex    = errors.New("unsupported git index")
+	errGitObject   = errors.New("git object not found")
+)
+
+// maxGitObjectSize is the largest object that is read from a pack, so that a corrupt size cannot use up memory.
+const maxGitObjectSize = 256 << 20
+
+type gitHash [20]byte
+
+// gitRepository reads the index and committed objects of a local git repository.
+type gitRepository struct {
+	workDir, gitDir string
+	packs           []*gitPack
+}
+
+// gitIndexEntry is the part of an index entry needed to tell if the working tree file has changed.
+type gitIndexEntry struct {
+	hash    gitHash
+	size    uint32
+	mtime   int64 // nanoseconds
+	mode    uint32
+	stage   int
+	assumed bool
+}
+
+// findGitRepository returns the repository containing the directory, looking through its parents.
+func findGitRepository(dir string) (*gitRepository, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		gitDir := filepath.Join(dir, ".git")
+		if info, err := os.Stat(gitDir); err == nil {
+			if !info.IsDir() { // a worktree or submodule refers to the real directory
+				if gitDir, err = readGitDirFile(gitDir); err != nil {
+					return nil, err
+				}
+			}
+			return &gitRepository{workDir: dir, gitDir: gitDir}, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, errGitNotFound
+		}
+		dir = parent
+	}
+}
+
+func readGitDirFile(file string) (string, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	line := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(line, "gitdir:") {
+		return "", errGitNotFound
+	}
+	dir := strings.TrimSpace(strings.TrimPrefix(line, "gitdir:"))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(filepath.Dir(file), dir)
+	}
+	return dir, nil
+}
+
+// headFiles returns the hash of every file in the commit at HEAD, by path.
+// A repository without any commits has no files.
+func (r *gitRepository) headFiles() (map[string]gitHash, error) {
+	files := make(map[string]gitHash)
+	commit, err := r.resolveRef("HEAD")
+	if err != nil {
+		return files, nil
+	}
+
+	typ, data, err := r.readObject(commit)
+	if err != nil {
+		return nil, err
+	}
+	if typ != "commit" || !bytes.HasPrefix(data, []byte("tree ")) || len(data) < 45 {
+		return nil, fmt.Errorf("HEAD is not a commit: %s", typ)
+	}
+	tree, err := parseHash(string(data[5:45]))
+	if err != nil {
+		return nil, err
+	}
+	return files, r.readTree(tree, "", files)
+}
+
+// readIndex parses the files staged in the index, by path.
+// Versions 2 to 4 of the format are supported.
+func (r *gitRepository) readIndex() (map[string]gitIndexEntry, error) {
+	entries := make(map[string]gitIndexEntry)
+	data, err := ioutil.ReadFile(filepath.Join(r.gitDir, "index"))
+	if os.IsNotExist(err) {
+		return entries, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if len(data) < 12 || string(data[:4]) != "DIRC" {
+		return nil, errGitIndex
+	}
+	version := binary.BigEndian.Uint32(data[4:])
+	if version < 2 || version > 4 {
+		return nil, errGitIndex
+	}
+
+	count := int(binary.BigEndian.Uint32(data[8:]))
+	pos := 12
+	var name string
+	for i := 0; i < count; i++ {
+		if pos+62 > len(data) {
+			return nil, errGitIndex
+		}
+		e := data[pos:]
+		entry := gitIndexEntry{
+			mtime: int64(binary.BigEndian.Uint32(e[8:]))*1e9 + int64(binary.BigEndian.Uint32(e[12:])),
+			mode:  binary.BigEndian.Uint32(e[24:]),
+			size:  binary.BigEndian.Uint32(e[36:]),
+		}
+		copy(entry.hash[:], e[40:60])
+		flags := binary.BigEndian.Uint16(e[60:])
+		entry.assumed = flags&0x8000 != 0
+		entry.stage = int(flags>>12) & 3
+		pos += 62
+		if flags&0x4000 != 0 && version >= 3 { // extended flags
+			pos += 2
+		}
+
+		if version == 4 { // the name replaces the end of the previous name
+			strip, n := binary.Uvarint(data[pos:])
+			if n <= 0 || int(strip) > len(name) {
+				return nil, errGitIndex
+			}
+			pos += n
+			end := bytes.IndexByte(data[pos:], 0)
+			if end == -1 {
+				return nil, errGitIndex
+			}
+			name = name[:len(name)-int(strip)] + string(data[pos:pos+end])
+			pos += end + 1
+		} else {
+			start := pos - 62
+			if flags&0x4000 != 0 && version >= 3 {
+				start -= 2
+			}
+			end := bytes.IndexByte(data[pos:], 0)
+			if end == -1 {
+				return nil, errGitIndex
+			}
+			name = string(data[pos : pos+end])
+			pos += end + 1
+			pos += (8 - (pos-start)%8) % 8 // entries are padded with NUL to a multiple of 8 bytes
+		}
+
+		if old, ok := entries[name]; ok && old.stage > entry.stage {
+			continue
+		}
+		entries[name] = entry
+	}
+	return entries, nil
+}
+
+// readObject returns the type and content of an object, from the loose objects or the packs.
+func (r *gitRepository) readObject(h gitHash) (string, []byte, error) {
+	id := hex.EncodeToString(h[:])
+	f, err := os.Open(filepath.Join(r.gitDir, "objects", id[:2], id[2:]))
+	if err == nil {
+		defer f.Close()
+		return readLooseObject(f)
+	}
+
+	if r.packs == nil {
+		if r.packs, err = openGitPacks(filepath.Join(r.gitDir, "objects", "pack")); err != nil {
+			return "", nil, err
+		}
+	}
+	for _, p := range r.packs {
+		if offset, ok := p.find(h); ok {
+			return p.read(r, offset)
+		}
+	}
+	return "", nil, errGitObject
+}
+
+func (r *gitRepository) readTree(h gitHash, prefix string, files map[string]gitHash) error {
+	typ, data, err := r.readObject(h)
+	if err != nil {
+		return err
+	}
+	if typ != "tree" {
+		return fmt.Errorf("expected a tree object, found %s", typ)
+	}
+
+	for len(data) > 0 {
+		space := bytes.IndexByte(data, ' ')
+		nul := bytes.IndexByte(data, 0)
+		if space == -1 || nul < space || nul+21 > len(data) {
+			return errGitObject
+		}
+		mode, name := string(data[:space]), prefix+string(data[space+1:nul])
+		var child gitHash
+		copy(child[:], data[nul+1:nul+21])
+		data = data[nul+21:]
+
+		switch mode {
+		case "40000":
+			if err := r.readTree(child, name+"/", files); err != nil {
+				return err
+			}
+		case "160000": // a submodule commit, not a file in this repository
+		default:
+			files[name] = child
+		}
+	}
+	return nil
+}
+
+// resolveRef returns the commit that a reference, such as HEAD or refs/heads/master, points to.
+func (r *gitRepository) resolveRef(ref string) (gitHash, error) {
+	for depth := 0; depth < 10; depth++ {
+		data, err := ioutil.ReadFile(filepath.Join(r.gitDir, filepath.FromSlash(ref)))
+		if err != nil {
+			return r.packedRef(ref)
+		}
+		line := strings.TrimSpace(string(data))
+		if !strings.HasPrefix(line, "ref:") {
+			return parseHash(line)
+		}
+		ref = strings.TrimSpace(strings.TrimPrefix(line, "ref:"))
+	}
+	return gitHash{}, errGitObject
+}
+
+func (r *gitRepository) packedRef(ref string) (gitHash, error) {
+	data, err := ioutil.ReadFile(filepath.Join(r.gitDir, "packed-refs"))
+	if err != nil {
+		return gitHash{}, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 && fields[1] == ref {
+			return parseHash(fields[0])
+		}
+	}
+	return gitHash{}, errGitObject
+}
+
+// hashBlob returns the hash that git gives to a file with the given content.
+func hashBlob(content []byte) gitHash {
+	h := sha1.New()
+	fmt.Fprintf(h, "blob %d\x00", len(content))
+	h.Write(content)
+	var sum gitHash
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
+
+func parseHash(s string) (h gitHash, err error) {
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != len(h) {
+		return h, errGitObject
+	}
+	copy(h[:], b)
+	return h, nil
+}
+
+func readLooseObject(in io.Reader) (string, []byte, error) {
+	z, err := zlib.NewReader(in)
+	if err != nil {
+		return "", nil, err
+	}
+	defer z.Close()
+	data, err := ioutil.ReadAll(z)
+	if err != nil {
+		return "", nil, err
+	}
+
+	nul := bytes.IndexByte(data, 0)
+	space := bytes.IndexByte(data, ' ')
+	if nul == -1 || space == -1 || space > nul {
+		return "", nil, errGitObject
+	}
+	size, err := strconv.Atoi(string(data[space+1 : nul]))
+	if err != nil || size != len(data)-nul-1 {
+		return "", nil, errGitObject
+	}
+	return string(data[:space]), data[nul+1:], nil
+}
+
+// gitPack reads objects from a pack file using the version 2 index alongside it.
+type gitPack struct {
+	file   string
+	hashes []byte
+	// offsets is the table of 32 bit offsets, large offsets are found in the following table
+	offsets, large []byte
+}
+
+var gitObjectTypes = map[byte]string{1: "commit", 2: "tree", 3: "blob", 4: "tag"}
+
+func openGitPacks(dir string) ([]*gitPack, error) {
+	indexes, err := filepath.Glob(filepath.Join(dir, "*.idx"))
+	if err != nil {
+		return nil, err
+	}
+
+	packs := []*gitPack{}
+	for _, idx := range indexes {
+		data, err := ioutil.ReadFile(idx)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) < 8+256*4 || string(data[:4]) != "\377tOc" || binary.BigEndian.Uint32(data[4:]) != 2 {
+			continue
+		}
+		count := int(binary.BigEndian.Uint32(data[8+255*4:]))
+		hashes := 8 + 256*4
+		offsets := hashes + count*20 + count*4
+		if len(data) < offsets+count*4 {
+			continue
+		}
+		packs = append(packs, &gitPack{
+			file:    strings.TrimSuffix(idx, ".idx") + ".pack",
+			hashes:  data[hashes : hashes+count*20],
+			offsets: data[offsets : offsets+count*4],
+			large:   data[offsets+count*4:],
+		})
+	}
+	return packs, nil
+}
+
+func (p *gitPack) find(h gitHash) (int64, bool) {
+	lo, hi := 0, len(p.hashes)/20
+	for lo < hi {
+		mid := (lo + hi) / 2
+		switch bytes.Compare(p.hashes[mid*20:mid*20+20], h[:]) {
+		case 0:
+			offset := binary.BigEndian.Uint32(p.offsets[mid*4:])
+			if offset&0x80000000 == 0 {
+				return int64(offset), true
+			}
+			i := int(offset&0x7fffffff) * 8
+			if i+8 > len(p.large) {
+				return 0, false
+			}
+			return int64(binary.BigEndian.Uint64(p.large[i:])), true
+		case -1:
+			lo = mid + 1
+		default:
+			hi = mid
+		}
+	}
+	return 0, false
+}
+
+// read returns the object at an offset in the pack, applying any deltas it is stored as.
+func (p *gitPack) read(r *gitRepository, offset int64) (string, []byte, error) {
+	f, err := os.Open(p.file)
+	if err != nil {
+		return "", nil, err
+	}
+	defer f.Close()
+	return p.readAt(r, f, offset)
+}
+
+func (p *gitPack) readAt(r *gitRepository, f *os.File, offset int64) (string, []byte, error) {
+	header := make([]byte, 32)
+	n, err := f.ReadAt(header, offset)
+	if n == 0 {
+		return "", nil, err
+	}
+	header = header[:n]
+
+	typ := (header[0] >> 4) & 7
+	size := int64(header[0] & 15)
+	pos, shift := 1, uint(4)
+	for header[pos-1]&0x80 != 0 {
+		if pos >= len(header) || shift > 32 {
+			return "", nil, errGitObject
+		}
+		size |= int64(header[pos]&0x7f) << shift
+		shift += 7
+		pos++
+	}
+	if size > maxGitObjectSize {
+		return "", nil, errGitObject
+	}
+
+	var base func() (string, []byte, error)
+	switch typ {
+	case 6: // the base is earlier in this pack
+		if pos >= len(header) {
+			return "", nil, errGitObject
+		}
+		rel := int64(header[pos] & 0x7f)
+		for header[pos]&0x80 != 0 {
+			pos++
+			if pos >= len(header) || rel > offset {
+				return "", nil, errGitObject
+			}
+			rel = ((rel + 1) << 7) | int64(header[pos]&0x7f)
+		}
+		pos++
+		if rel == 0 || rel > offset { // the base must be before this object
+			return "", nil, errGitObject
+		}
+		base = func() (string, []byte, error) {
+			return p.readAt(r, f, offset-rel)
+		}
+	case 7: // the base is named by its hash
+		if pos+20 > len(header) {
+			return "", nil, errGitObject
+		}
+		var h gitHash
+		copy(h[:], header[pos:pos+20])
+		pos += 20
+		base = func() (string, []byte, error) {
+			return r.readObject(h)
+		}
+	}
+
+	z, err := zlib.NewReader(io.NewSectionReader(f, offset+int64(pos), 1<<62))
+	if err != nil {
+		return "", nil, err
+	}
+	defer z.Close()
+	data := make([]byte, size)
+	if _, err = io.ReadFull(z, data); err != nil {
+		return "", nil, err
+	}
+
+	if base == nil {
+		name, ok := gitObjectTypes[typ]
+		if !ok {
+			return "", nil, errGitObject
+		}
+		return name, data, nil
+	}
+	baseType, baseData, err := base()
+	if err != nil {
+		return "", nil, err
+	}
+	data, err = applyGitDelta(baseData, data)
+	return baseType, data, err
+}
+
+// applyGitDelta builds an object from a delta, which copies ranges of the base or inserts new data.
+func applyGitDelta(base, delta []byte) ([]byte, error) {
+	readSize := func() int {
+		size, shift := 0, uint(0)
+		for len(delta) > 0 {
+			b := delta[0]
+			delta = delta[1:]
+			size |= int(b&0x7f) << shift
+			shift += 7
+			if b&0x80 == 0 {
+				break
+			}
+		}
+		return size
+	}
+	if readSize() != len(base) {
+		return nil, errGitObject
+	}
+	size := readSize()
+	if size < 0 || size > maxGitObjectSize {
+		return nil, errGitObject
+	}
+	out := make([]byte, 0, size)
+
+	for len(delta) > 0 {
+		op := delta[0]
+		delta = delta[1:]
+		if op&0x80 == 0 { // insert
+			n := int(op)
+			if n == 0 || n > len(delta) {
+				return nil, errGitObject
+			}
+			out = append(out, delta[:n]...)
+			delta = delta[n:]
+			continue
+		}
+
+		var offset, size int
+		for i := uint(0); i < 7; i++ {
+			if op&(1<<i) == 0 {
+				continue
+			}
+			if len(delta) == 0 {
+				return nil, errGitObject
+			}
+			if i < 4 {
+				offset |= int(delta[0]) << (8 * i)
+			} else {
+				size |= int(delta[0]) << (8 * (i - 4))
+			}
+			delta = delta[1:]
+		}
+		if size == 0 {
+			size = 0x10000
+		}
+		if offset+size > len(base) {
+			return nil, errGitObject
+		}
+		out = append(out, base[offset:offset+size]...)
+	}
+	return out, nil
+}
@@ -0,0 +1,237 @@
+package widget
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// hexBlank is the segment state with every segment off.
+const hexBlank uint8 = 0xff
+
+// hexMinus is the segment state with only the middle segment on, used for a minus sign and to indicate overflow.
+const hexMinus uint8 = hexBlank &^ (1 << 6)
+
+// HexDisplay shows a number across a row of HexWidget digits, in hexadecimal, decimal or any other base
+// up to 16. Negative numbers are shown with a minus sign in front of their magnitude, and numbers that do
+// not fit are shown as a dash in every digit. The colour, size and slant are shared by all of the digits.
+type HexDisplay struct {
+	widget.BaseWidget
+
+	digits       []*HexWidget
+	base         uint
+	value        int64
+	leadingZeros bool
+	overflow     bool
+
+	size      fyne.Size
+	hexOffset float32
+	onColor   color.Color
+	offColor  color.Color
+}
+
+// NewHexDisplay creates a display with the given number of digits, showing 0 in hexadecimal.
+func NewHexDisplay(digits int) *HexDisplay {
+	h := &HexDisplay{
+		base:      16,
+		size:      fyne.NewSize(defaultHexWidth, defaultHexHeight),
+		hexOffset: defaultHexOffset,
+	}
+	h.ExtendBaseWidget(h)
+	h.SetDigits(digits)
+	return h
+}
+
+// CreateRenderer implements fyne.Widget
+func (h *HexDisplay) CreateRenderer() fyne.WidgetRenderer {
+	r := &digitRowRenderer{digits: func() []fyne.CanvasObject {
+		objects := make([]fyne.CanvasObject, len(h.digits))
+		for i, d := range h.digits {
+			objects[i] = d
+		}
+		return objects
+	}}
+	r.Refresh()
+	return r
+}
+
+// Overflow returns true if the value does not fit in the digits of the display.
+func (h *HexDisplay) Overflow() bool {
+	return h.overflow
+}
+
+// SetBase changes the base that the value is shown in, such as 10 for decimal or 16 for hexadecimal.
+// Bases outside 2 to 16 are ignored.
+func (h *HexDisplay) SetBase(base uint) {
+	if base < 2 || base > 16 {
+		return
+	}
+	h.base = base
+	h.update()
+}
+
+// SetDigits changes the number of digits in the display, which is at least 1.
+func (h *HexDisplay) SetDigits(count int) {
+	if count < 1 {
+		count = 1
+	}
+	for len(h.digits) > count {
+		h.digits = h.digits[:len(h.digits)-1]
+	}
+	for len(h.digits) < count {
+		d := NewHexWidget()
+		d.size, d.hexOffset, d.hexOnColor, d.hexOffColor = h.size, h.hexOffset, h.onColor, h.offColor
+		h.digits = append(h.digits, d)
+	}
+	h.update()
+}
+
+// SetLeadingZeros sets whether unused digits on the left are shown as 0, instead of being left blank.
+func (h *HexDisplay) SetLeadingZeros(show bool) {
+	h.leadingZeros = show
+	h.update()
+}
+
+// SetOffColor changes the color that segments are shown as when they are inactive/off.
+// Passing nil returns to a faint version of the on color.
+func (h *HexDisplay) SetOffColor(c color.Color) {
+	h.offColor = c
+	for _, d := range h.digits {
+		d.SetOffColor(c)
+	}
+}
+
+// SetOnColor changes the color that segments are shown as when they are active/on.
+// Passing nil returns to the primary color of the theme.
+func (h *HexDisplay) SetOnColor(c color.Color) {
+	h.onColor = c
+	for _, d := range h.digits {
+		d.SetOnColor(c)
+	}
+}
+
+// SetSize changes the size of each digit of the display.
+func (h *HexDisplay) SetSize(s fyne.Size) {
+	h.size = s
+	for _, d := range h.digits {
+		d.SetSize(s)
+	}
+	h.Refresh()
+}
+
+// SetSlant changes the amount of "slant" in each digit, see HexWidget.SetSlant.
+func (h *HexDisplay) SetSlant(s float32) {
+	h.hexOffset = s
+	for _, d := range h.digits {
+		d.SetSlant(s)
+	}
+	h.Refresh()
+}
+
+// SetValue changes the number shown by the display.
+func (h *HexDisplay) SetValue(v int64) {
+	h.value = v
+	h.update()
+}
+
+// Value returns the number shown by the display.
+func (h *HexDisplay) Value() int64 {
+	return h.value
+}
+
+// segments returns the segment states of each digit to show the value, from left to right.
+func (h *HexDisplay) segments() []uint8 {
+	count := len(h.digits)
+	segments := make([]uint8, count)
+
+	magnitude := uint64(h.value)
+	if h.value < 0 {
+		magnitude = uint64(-h.value) // the most negative value wraps to its own magnitude, which is correct
+	}
+	var numerals []uint8
+	for {
+		numerals = append(numerals, segmentLookupTable[magnitude%uint64(h.base)])
+		magnitude /= uint64(h.base)
+		if magnitude == 0 {
+			break
+		}
+	}
+
+	used := len(numerals)
+	if h.value < 0 {
+		used++
+	}
+	h.overflow = used > count
+	if h.overflow {
+		for i := range segments {
+			segments[i] = hexMinus
+		}
+		return segments
+	}
+
+	for i := range segments {
+		segments[i] = hexBlank
+		if h.leadingZeros {
+			segments[i] = segmentLookupTable[0]
+		}
+	}
+	for i, n := range numerals {
+		segments[count-1-i] = n
+	}
+	if h.value < 0 {
+		sign := count - len(numerals) - 1
+		if h.leadingZeros {
+			sign = 0
+		}
+		segments[sign] = hexMinus
+	}
+	return segments
+}
+
+func (h *HexDisplay) update() {
+	for i, s := range h.segments() {
+		h.digits[i].UpdateSegments(s)
+	}
+	h.Refresh()
+}
+
+// digitRowRenderer lays out the digits of a display side by side at their minimum size.
+type digitRowRenderer struct {
+	digits  func() []fyne.CanvasObject
+	objects []fyne.CanvasObject
+}
+
+func (r *digitRowRenderer) Destroy() {
+}
+
+func (r *digitRowRenderer) Layout(fyne.Size) {
+	x := float32(0)
+	for _, o := range r.objects {
+		min := o.MinSize()
+		o.Move(fyne.NewPos(x, 0))
+		o.Resize(min)
+		x += min.Width
+	}
+}
+
+func (r *digitRowRenderer) MinSize() fyne.Size {
+	size := fyne.NewSize(0, 0)
+	for _, o := range r.objects {
+		min := o.MinSize()
+		size.Width += min.Width
+		if min.Height > size.Height {
+			size.Height = min.Height
+		}
+	}
+	return size
+}
+
+func (r *digitRowRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *digitRowRenderer) Refresh() {
+	r.objects = r.digits()
+	r.Layout(fyne.Size{})
+}
@@ -0,0 +1,88 @@
+package widget
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestHexDisplay_SetValue(t *testing.T) {
+	h := NewHexDisplay(4)
+	h.SetValue(0x1f)
+	assert.Equal(t, []uint8{hexBlank, hexBlank, segmentLookupTable[1], segmentLookupTable[0xf]}, displayedSegments(h))
+
+	h.SetLeadingZeros(true)
+	assert.Equal(t, []uint8{segmentLookupTable[0], segmentLookupTable[0], segmentLookupTable[1], segmentLookupTable[0xf]}, displayedSegments(h))
+
+	h.SetLeadingZeros(false)
+	h.SetBase(10)
+	assert.Equal(t, []uint8{hexBlank, hexBlank, segmentLookupTable[3], segmentLookupTable[1]}, displayedSegments(h))
+
+	h.SetValue(0)
+	assert.Equal(t, []uint8{hexBlank, hexBlank, hexBlank, segmentLookupTable[0]}, displayedSegments(h))
+	assert.False(t, h.Overflow())
+}
+
+func TestHexDisplay_Negative(t *testing.T) {
+	h := NewHexDisplay(4)
+	h.SetBase(10)
+	h.SetValue(-42)
+	assert.Equal(t, []uint8{hexBlank, hexMinus, segmentLookupTable[4], segmentLookupTable[2]}, displayedSegments(h))
+	assert.Equal(t, int64(-42), h.Value())
+
+	h.SetLeadingZeros(true)
+	assert.Equal(t, []uint8{hexMinus, segmentLookupTable[0], segmentLookupTable[4], segmentLookupTable[2]}, displayedSegments(h))
+
+	h.SetValue(-999)
+	assert.False(t, h.Overflow())
+	h.SetValue(-1000) // the sign needs a digit too
+	assert.True(t, h.Overflow())
+	assert.Equal(t, []uint8{hexMinus, hexMinus, hexMinus, hexMinus}, displayedSegments(h))
+}
+
+func TestHexDisplay_Overflow(t *testing.T) {
+	h := NewHexDisplay(2)
+	h.SetValue(0x100)
+	assert.True(t, h.Overflow())
+	assert.Equal(t, []uint8{hexMinus, hexMinus}, displayedSegments(h))
+
+	h.SetDigits(3)
+	assert.False(t, h.Overflow())
+	assert.Equal(t, []uint8{segmentLookupTable[1], segmentLookupTable[0], segmentLookupTable[0]}, displayedSegments(h))
+
+	h.SetBase(1) // ignored
+	assert.Equal(t, uint(16), h.base)
+}
+
+func TestHexDisplay_SharedSettings(t *testing.T) {
+	test.NewApp()
+
+	h := NewHexDisplay(3)
+	h.SetSize(fyne.NewSize(20, 40))
+	h.SetSlant(0)
+	window := test.NewWindow(h)
+	defer window.Close()
+
+	for _, d := range h.digits {
+		assert.Equal(t, fyne.NewSize(20, 40), d.size)
+		assert.Equal(t, float32(0), d.hexOffset)
+	}
+	digit := h.digits[0].MinSize()
+	assert.Equal(t, fyne.NewSize(digit.Width*3, digit.Height), h.MinSize())
+
+	h.SetDigits(4)
+	assert.Equal(t, fyne.NewSize(20, 40), h.digits[3].size)
+	assert.Equal(t, digit.Width*4, h.MinSize().Width)
+	assert.Equal(t, fyne.NewPos(digit.Width*3, 0), h.digits[3].Position())
+}
+
+func displayedSegments(h *HexDisplay) []uint8 {
+	segments := make([]uint8, len(h.digits))
+	for i, d := range h.digits {
+		segments[i] = d.segments
+	}
+	return segments
+}
@@ -2,6 +2,8 @@ package widget
 
 import (
 	"image/color"
+	"math"
+	"sync"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -11,26 +13,29 @@ import (
 
 // segmentLookupTable is used by h.Set() - the i-th index into this table
 // represents the raw value that should be sent to UpdateSegments to show
-// the value i.
+// the value i. Bit 7 is set in every entry so that the decimal point is off.
 var segmentLookupTable []uint8 = []uint8{
-	1 << 6,
+	1<<6 | (1 << 7),
 	(1<<0 | (1 << 1) | (1 << 2) | (1 << 3) | (1 << 6) | (1 << 7)),
-	(1<<2 | (1 << 5)),
-	(1<<4 | (1 << 5)),
-	(1<<0 | (1 << 3) | (1 << 4)),
-	(1<<1 | (1 << 4)),
-	(1 << 1),
-	(1<<3 | (1 << 4) | (1 << 5) | (1 << 6)),
-	0,
-	(1<<3 | (1 << 4)),
-	(1 << 3),
-	(1<<0 | (1 << 1)),
-	(1<<0 | (1 << 1) | (1 << 2) | (1 << 5)),
-	(1<<0 | (1 << 5)),
-	(1<<1 | (1 << 2)),
-	(1<<1 | (1 << 2) | (1 << 3)),
+	(1<<2 | (1 << 5) | (1 << 7)),
+	(1<<4 | (1 << 5) | (1 << 7)),
+	(1<<0 | (1 << 3) | (1 << 4) | (1 << 7)),
+	(1<<1 | (1 << 4) | (1 << 7)),
+	(1<<1 | (1 << 7)),
+	(1<<3 | (1 << 4) | (1 << 5) | (1 << 6) | (1 << 7)),
+	(1 << 7),
+	(1<<3 | (1 << 4) | (1 << 7)),
+	(1<<3 | (1 << 7)),
+	(1<<0 | (1 << 1) | (1 << 7)),
+	(1<<0 | (1 << 1) | (1 << 2) | (1 << 5) | (1 << 7)),
+	(1<<0 | (1 << 5) | (1 << 7)),
+	(1<<1 | (1 << 2) | (1 << 7)),
+	(1<<1 | (1 << 2) | (1 << 3) | (1 << 7)),
 }
 
+// hexDecimalPoint is the bit of the segment state that controls the decimal point.
+const hexDecimalPoint uint8 = 1 << 7
+
 // size of the hex widget
 const defaultHexHeight float32 = 75.0
 const defaultHexWidth float32 = defaultHexHeight * (7.5 / 14.0)
@@ -38,12 +43,22 @@ const defaultHexWidth float32 = defaultHexHeight * (7.5 / 14.0)
 // slant angle
 const defaultHexOffset float32 = 0.1 * defaultHexWidth
 
-var defaultHexOnColor color.RGBA = color.RGBA{200, 25, 25, 255}
-var defaultHexOffColor color.RGBA = color.RGBA{25, 15, 15, 64}
+// hexSegmentGap is the space left between the ends of neighbouring segments, relative to their width.
+const hexSegmentGap float32 = 0.1
 
 type hexRenderer struct {
-	hex            *HexWidget
-	segmentObjects []fyne.CanvasObject
+	hex        *HexWidget
+	segments   *canvas.Raster
+	point      *canvas.Circle
+	colon      [2]*canvas.Circle
+	apostrophe *canvas.Line
+	objects    []fyne.CanvasObject
+
+	// the outline and colour of each segment, read when the raster is drawn
+	polygons   [7][]fyne.Position
+	colors     [7]color.Color
+	paintLock  sync.RWMutex
+	paintScale fyne.Size
 }
 
 func (h *hexRenderer) MinSize() fyne.Size {
@@ -64,14 +79,6 @@ func (h *hexRenderer) Refresh() {
 	hexSegmentVLength := (9.14 / (2 * 14)) * h.hex.size.Height
 	hexSegmentHLength := (4.8 / 7.5) * h.hex.size.Width
 
-	h.segmentObjects[0].(*canvas.Line).StrokeWidth = float32(hexSegmentWidth / 2)
-	h.segmentObjects[1].(*canvas.Line).StrokeWidth = float32(hexSegmentWidth / 2)
-	h.segmentObjects[2].(*canvas.Line).StrokeWidth = float32(hexSegmentWidth / 2)
-	h.segmentObjects[3].(*canvas.Line).StrokeWidth = float32(hexSegmentWidth / 2)
-	h.segmentObjects[4].(*canvas.Line).StrokeWidth = float32(hexSegmentWidth / 2)
-	h.segmentObjects[5].(*canvas.Line).StrokeWidth = float32(hexSegmentWidth / 2)
-	h.segmentObjects[6].(*canvas.Line).StrokeWidth = float32(hexSegmentWidth / 2)
-
 	pos := fyne.NewPos(h.hex.hexOffset, 0)
 
 	pt0Center := fyne.NewPos(pos.X+h.hex.size.Width/2.0+h.hex.hexOffset, pos.Y)
@@ -86,72 +93,141 @@ func (h *hexRenderer) Refresh() {
 	pt34 := fyne.NewPos(float32(pt3Center.X)-(hexSegmentHLength/2), pt3Center.Y)
 	pt32 := fyne.NewPos(float32(pt3Center.X)+(hexSegmentHLength/2), pt3Center.Y)
 
-	setLineEndpoints(h.segmentObjects[0].(*canvas.Line), pt05, pt01)
-	setLineEndpoints(h.segmentObjects[1].(*canvas.Line), pt01, pt61)
-	setLineEndpoints(h.segmentObjects[2].(*canvas.Line), pt61, pt32)
-	setLineEndpoints(h.segmentObjects[3].(*canvas.Line), pt32, pt34)
-	setLineEndpoints(h.segmentObjects[4].(*canvas.Line), pt34, pt65)
-	setLineEndpoints(h.segmentObjects[5].(*canvas.Line), pt65, pt05)
-	setLineEndpoints(h.segmentObjects[6].(*canvas.Line), pt65, pt61)
-
-	for i, v := range h.segmentObjects {
-		v.(*canvas.Line).StrokeColor = h.hex.getSegmentColor(i)
-		canvas.Refresh(v)
+	ends := [7][2]fyne.Position{{pt05, pt01}, {pt01, pt61}, {pt61, pt32}, {pt32, pt34}, {pt34, pt65}, {pt65, pt05}, {pt65, pt61}}
+	h.paintLock.Lock()
+	for i, e := range ends {
+		h.polygons[i] = segmentPolygon(e[0], e[1], hexSegmentWidth/2)
+		h.colors[i] = h.hex.getSegmentColor(i)
+	}
+	h.paintScale = h.MinSize()
+	h.paintLock.Unlock()
+	h.segments.Move(fyne.NewPos(0, 0))
+	h.segments.Resize(h.MinSize())
+	canvas.Refresh(h.segments)
+
+	on, _ := segmentColors(h.hex.hexOnColor, h.hex.hexOffColor)
+
+	// the decimal point, colon and apostrophe sit to the right of the digit, following its slant
+	dotSize := hexSegmentWidth * 0.6
+	dotX := func(y float32) float32 {
+		return pt6Center.X + hexSegmentHLength/2 + hexSegmentWidth + h.hex.hexOffset*(1-y/hexSegmentVLength)
+	}
+	setDot := func(dot *canvas.Circle, y float32, c color.Color) {
+		dot.FillColor = c
+		dot.Move(fyne.NewPos(dotX(y)-dotSize/2, y-dotSize/2))
+		dot.Resize(fyne.NewSize(dotSize, dotSize))
+		canvas.Refresh(dot)
+	}
+
+	setDot(h.point, pt3Center.Y, h.hex.getSegmentColor(7))
+	for i, dot := range h.colon {
+		dot.Hidden = !h.hex.colon
+		setDot(dot, hexSegmentVLength*(0.5+float32(i)), on)
 	}
+
+	h.apostrophe.Hidden = !h.hex.apostrophe
+	h.apostrophe.StrokeColor = on
+	h.apostrophe.StrokeWidth = dotSize / 2
+	top := fyne.NewPos(dotX(0)+dotSize/4, 0)
+	bottom := fyne.NewPos(dotX(hexSegmentVLength/3)-dotSize/4, hexSegmentVLength/3)
+	setLineEndpoints(h.apostrophe, top, bottom)
+	canvas.Refresh(h.apostrophe)
 }
 
 func (h *hexRenderer) Destroy() {
 }
 
+// paint draws the segments into the raster, sampling four points of each pixel to smooth the edges.
+func (h *hexRenderer) paint(x, y, w, height int) color.Color {
+	h.paintLock.RLock()
+	defer h.paintLock.RUnlock()
+	scaleX, scaleY := h.paintScale.Width/float32(w), h.paintScale.Height/float32(height)
+
+	for i, polygon := range h.polygons {
+		covered := 0
+		for _, sample := range [4][2]float32{{0.25, 0.25}, {0.75, 0.25}, {0.25, 0.75}, {0.75, 0.75}} {
+			p := fyne.NewPos((float32(x)+sample[0])*scaleX, (float32(y)+sample[1])*scaleY)
+			if insideConvexPolygon(polygon, p) {
+				covered++
+			}
+		}
+		if covered > 0 {
+			c := color.NRGBAModel.Convert(h.colors[i]).(color.NRGBA)
+			c.A = uint8(int(c.A) * covered / 4)
+			return c
+		}
+	}
+	return color.NRGBA{} // the raster takes its image type from the first pixel, so this must not be color.Transparent
+}
+
 func (h *hexRenderer) Objects() []fyne.CanvasObject {
-	return h.segmentObjects
+	return h.objects
 }
 
-// HexWidget represents a 7-segment hexadecimal display. The segments
-// of the display mapped active-low onto 7 state bits, with segment 0 in
-// the least significant bit.
+// HexWidget represents a 7-segment hexadecimal display with a decimal point.
+// The segments of the display are mapped active-low onto 8 state bits, with
+// segment 0 in the least significant bit and the decimal point (7) in the most
+// significant bit. A colon and an apostrophe can also be shown to the right of
+// the digit, such as for the time "12:30".
 //
 //       0
-//     -----
+//     -----     '
 //    |     |
-//  5 |     | 1
+//  5 |     | 1  .
 //    |  6  |
 //     -----
-//    |     |
+//    |     |    .
 //  4 |     | 2
 //    |  3  |
-//     -----
+//     -----  .7
 type HexWidget struct {
 	widget.BaseWidget
 	segments uint8
 
+	// whether the colon and apostrophe are shown
+	colon, apostrophe bool
+
 	// size of the hex widget
 	size fyne.Size
 
 	// slant angle
 	hexOffset float32
 
-	// color when the hex is on
-	hexOnColor color.RGBA
+	// color when the hex is on, or nil to follow the theme
+	hexOnColor color.Color
 
-	// color when the hex is off
-	hexOffColor color.RGBA
+	// color when the hex is off, or nil to follow the theme
+	hexOffColor color.Color
 }
 
 // SetOnColor changes the color that segments are shown as when they are
-// active/on.
-func (h *HexWidget) SetOnColor(c color.RGBA) {
+// active/on. Passing nil returns to the primary color of the theme.
+func (h *HexWidget) SetOnColor(c color.Color) {
 	h.hexOnColor = c
 	h.Refresh()
 }
 
 // SetOffColor changes the color that segments are shown as when they are
-// inactive/off.
-func (h *HexWidget) SetOffColor(c color.RGBA) {
+// inactive/off. Passing nil returns to a faint version of the on color.
+func (h *HexWidget) SetOffColor(c color.Color) {
 	h.hexOffColor = c
 	h.Refresh()
 }
 
+// SetApostrophe sets whether an apostrophe is shown at the top right of the
+// digit, such as to mark minutes.
+func (h *HexWidget) SetApostrophe(show bool) {
+	h.apostrophe = show
+	h.Refresh()
+}
+
+// SetColon sets whether a colon is shown to the right of the digit, such as
+// between the hours and minutes of a clock.
+func (h *HexWidget) SetColon(show bool) {
+	h.colon = show
+	h.Refresh()
+}
+
 // SetSize changes the size of the hex widget.
 func (h *HexWidget) SetSize(s fyne.Size) {
 	h.size = s
@@ -172,29 +248,27 @@ func setLineEndpoints(l *canvas.Line, pt1, pt2 fyne.Position) {
 	l.Resize(fyne.NewSize(float32(pt2.X-pt1.X), float32(pt2.Y-pt1.Y)))
 }
 
-func (h *HexWidget) getSegmentColor(segno int) color.RGBA {
+func (h *HexWidget) getSegmentColor(segno int) color.Color {
+	on, off := segmentColors(h.hexOnColor, h.hexOffColor)
 	if (h.segments & (1 << uint(segno))) == 0 {
-		return h.hexOnColor
+		return on
 	}
 
-	return h.hexOffColor
+	return off
 }
 
 // CreateRenderer implements fyne.Widget
 func (h *HexWidget) CreateRenderer() fyne.WidgetRenderer {
 
-	seg0 := canvas.NewLine(h.hexOffColor)
-	seg1 := canvas.NewLine(h.hexOffColor)
-	seg2 := canvas.NewLine(h.hexOffColor)
-	seg3 := canvas.NewLine(h.hexOffColor)
-	seg4 := canvas.NewLine(h.hexOffColor)
-	seg5 := canvas.NewLine(h.hexOffColor)
-	seg6 := canvas.NewLine(h.hexOffColor)
-
+	on, off := segmentColors(h.hexOnColor, h.hexOffColor)
 	r := &hexRenderer{
-		hex:            h,
-		segmentObjects: []fyne.CanvasObject{seg0, seg1, seg2, seg3, seg4, seg5, seg6},
+		hex:        h,
+		point:      canvas.NewCircle(off),
+		colon:      [2]*canvas.Circle{canvas.NewCircle(on), canvas.NewCircle(on)},
+		apostrophe: canvas.NewLine(on),
 	}
+	r.segments = canvas.NewRasterWithPixels(r.paint)
+	r.objects = []fyne.CanvasObject{r.segments, r.point, r.colon[0], r.colon[1], r.apostrophe}
 
 	r.Refresh()
 
@@ -205,11 +279,9 @@ func (h *HexWidget) CreateRenderer() fyne.WidgetRenderer {
 // disabled.
 func NewHexWidget() *HexWidget {
 	h := &HexWidget{
-		segments:    0xff,
-		size:        fyne.NewSize(defaultHexWidth, defaultHexHeight),
-		hexOffset:   defaultHexOffset,
-		hexOnColor:  defaultHexOnColor,
-		hexOffColor: defaultHexOffColor,
+		segments:  0xff,
+		size:      fyne.NewSize(defaultHexWidth, defaultHexHeight),
+		hexOffset: defaultHexOffset,
 	}
 
 	h.ExtendBaseWidget(h)
@@ -220,6 +292,10 @@ func NewHexWidget() *HexWidget {
 // refresh so the changes are visible to the user. Segments values are packed
 // into the 8-bit segments integer, see the documentation for HexWidget for
 // more information on the appropriate packing.
+//
+// Bit 7 controls the decimal point, which earlier versions did not draw. As it
+// is active-low like the other segments, values that leave bit 7 clear now show
+// the decimal point: set it, such as with 0x80, to keep the point off.
 func (h *HexWidget) UpdateSegments(segments uint8) {
 	h.segments = segments
 	h.Refresh()
@@ -227,8 +303,75 @@ func (h *HexWidget) UpdateSegments(segments uint8) {
 
 // Set updates the hex widget to show a specific number between 0 and 15, which
 // will be rendered in hexadecimal in 0...f. If the number is greater than 15,
-// it will be modulo-ed by 16.
+// it will be modulo-ed by 16. The decimal point is turned off.
 func (h *HexWidget) Set(val uint) {
-	val = val % 16
-	h.UpdateSegments(segmentLookupTable[val])
+	h.SetWithPoint(val, false)
+}
+
+// SetWithPoint updates the hex widget to show a specific number like Set, and
+// turns the decimal point on or off, such as to show the "3." of "3.14".
+func (h *HexWidget) SetWithPoint(val uint, point bool) {
+	segments := segmentLookupTable[val%16]
+	if point {
+		segments &^= hexDecimalPoint
+	}
+	h.UpdateSegments(segments)
+}
+
+// insideConvexPolygon returns true if a point is inside a convex polygon, whose points may go either way round.
+func insideConvexPolygon(polygon []fyne.Position, p fyne.Position) bool {
+	var sign float32
+	for i, a := range polygon {
+		b := polygon[(i+1)%len(polygon)]
+		cross := (b.X-a.X)*(p.Y-a.Y) - (b.Y-a.Y)*(p.X-a.X)
+		if cross == 0 {
+			continue
+		}
+		if sign == 0 {
+			sign = cross
+		} else if (cross > 0) != (sign > 0) {
+			return false
+		}
+	}
+	return sign != 0
+}
+
+// segmentColors returns the colours of segments that are on and off. Colours that have not been set follow the
+// theme, with segments that are off shown as a faint version of those that are on.
+func segmentColors(on, off color.Color) (color.Color, color.Color) {
+	if on == nil {
+		on = theme.PrimaryColor()
+	}
+	if off == nil {
+		faint := color.NRGBAModel.Convert(on).(color.NRGBA)
+		faint.A /= 5
+		off = faint
+	}
+	return on, off
+}
+
+// segmentPolygon returns the outline of a bevelled segment between two points, pointed at each end like a hexagon
+// so that it fits against the neighbouring segments.
+func segmentPolygon(from, to fyne.Position, width float32) []fyne.Position {
+	from, to = shortenLine(from, to, width*hexSegmentGap)
+	dx, dy := to.X-from.X, to.Y-from.Y
+	length := float32(math.Hypot(float64(dx), float64(dy)))
+	if length == 0 {
+		return nil
+	}
+	half := width / 2
+	if half > length/2 {
+		half = length / 2
+	}
+	// along is a step along the segment, and across a step at right angles to it, each of half its width
+	along := fyne.NewPos(dx/length*half, dy/length*half)
+	across := fyne.NewPos(-along.Y, along.X)
+	return []fyne.Position{
+		from,
+		from.Add(along).Add(across),
+		to.Subtract(along).Add(across),
+		to,
+		to.Subtract(along).Subtract(across),
+		from.Add(along).Subtract(across),
+	}
 }
@@ -0,0 +1,105 @@
+package widget
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+)
+
+func TestHexWidget_DecimalPointOffByDefault(t *testing.T) {
+	for i, segments := range segmentLookupTable {
+		assert.NotZero(t, segments&hexDecimalPoint, "decimal point lit for %x", i)
+	}
+}
+
+func TestHexWidget_SetWithPoint(t *testing.T) {
+	test.NewApp()
+
+	h := NewHexWidget()
+	r := test.WidgetRenderer(h).(*hexRenderer)
+	on, off := segmentColors(nil, nil)
+	h.SetWithPoint(3, true)
+	assert.Equal(t, segmentLookupTable[3]&^hexDecimalPoint, h.segments)
+	assert.Equal(t, on, r.point.FillColor)
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, h.getSegmentColor(i), r.colors[i])
+	}
+
+	h.Set(3)
+	assert.Equal(t, segmentLookupTable[3], h.segments)
+	assert.Equal(t, off, r.point.FillColor)
+
+	h.SetWithPoint(0x13, true) // modulo 16, like Set
+	assert.Equal(t, segmentLookupTable[3]&^hexDecimalPoint, h.segments)
+}
+
+func TestHexWidget_ColonApostrophe(t *testing.T) {
+	test.NewApp()
+
+	h := NewHexWidget()
+	r := test.WidgetRenderer(h).(*hexRenderer)
+	assert.False(t, r.colon[0].Visible())
+	assert.False(t, r.colon[1].Visible())
+	assert.False(t, r.apostrophe.Visible())
+	assert.Len(t, r.Objects(), 5)
+
+	h.SetColon(true)
+	assert.True(t, r.colon[0].Visible())
+	assert.True(t, r.colon[1].Visible())
+	assert.Less(t, r.colon[0].Position().Y, r.colon[1].Position().Y)
+	assert.Greater(t, r.colon[0].Position().X, r.colon[1].Position().X) // follows the slant
+
+	h.SetApostrophe(true)
+	assert.True(t, r.apostrophe.Visible())
+	on, _ := segmentColors(nil, nil)
+	assert.Equal(t, on, r.apostrophe.StrokeColor)
+
+	h.SetSlant(0)
+	assert.Equal(t, r.colon[0].Position().X, r.colon[1].Position().X)
+	assert.Equal(t, r.colon[0].Position().X, r.point.Position().X)
+}
+
+func TestHexWidget_Colors(t *testing.T) {
+	test.NewApp()
+
+	h := NewHexWidget()
+	h.Set(1) // segments 4 and 5 are on, the rest off
+	w := test.NewWindow(h)
+	defer w.Close()
+	r := test.WidgetRenderer(h).(*hexRenderer)
+	on, off := segmentColors(nil, nil)
+	assert.Equal(t, theme.PrimaryColor(), on)
+	assert.Equal(t, on, r.colors[4])
+	assert.Equal(t, off, r.colors[0])
+	assert.IsType(t, color.NRGBA{}, r.paint(0, 0, 10, 10)) // sets the image type of the raster
+
+	test.ApplyTheme(t, test.NewTheme())
+	assert.NotEqual(t, on, theme.PrimaryColor())
+	assert.Equal(t, theme.PrimaryColor(), r.colors[4])
+
+	h.SetOnColor(color.White)
+	h.SetOffColor(color.Black)
+	assert.Equal(t, color.White, r.colors[4])
+	assert.Equal(t, color.Black, r.colors[0])
+	assert.Equal(t, color.White, r.apostrophe.StrokeColor)
+
+	h.SetOffColor(nil)
+	assert.Equal(t, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x33}, r.colors[0])
+}
+
+func TestHexWidget_SegmentPolygon(t *testing.T) {
+	p := segmentPolygon(fyne.NewPos(0, 0), fyne.NewPos(20, 0), 4)
+	assert.Len(t, p, 6)
+	assert.True(t, insideConvexPolygon(p, fyne.NewPos(10, 1.5)))
+	assert.True(t, insideConvexPolygon(p, fyne.NewPos(1, 0)))
+	assert.False(t, insideConvexPolygon(p, fyne.NewPos(10, 2.5)))
+	assert.False(t, insideConvexPolygon(p, fyne.NewPos(1, 1.5))) // cut off by the bevel
+	assert.False(t, insideConvexPolygon(p, fyne.NewPos(0.1, 0))) // shortened to leave a gap
+
+	assert.Nil(t, segmentPolygon(fyne.NewPos(1, 1), fyne.NewPos(1, 1), 4))
+}
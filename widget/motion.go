@@ -0,0 +1,150 @@
+package widget
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// MotionPolicy controls when the animated widgets in this package are allowed to play.
+type MotionPolicy int
+
+const (
+	// MotionAuto looks up the policy from the FYNE_X_MOTION environment variable, or if that is not set
+	// the MotionPreferenceKey of the app preferences. If neither is set animations play as MotionFull.
+	MotionAuto MotionPolicy = iota
+	// MotionFull always plays animations.
+	MotionFull
+	// MotionPowerSaving pauses animations that are hidden, are not shown in a window, whose window has been closed
+	// or whose window has been reported inactive with SetWindowActive.
+	MotionPowerSaving
+	// MotionReduced shows a static frame instead of playing animations.
+	MotionReduced
+)
+
+// MotionPreferenceKey is the app preference key that can store the motion policy.
+// The value should be one of "full", "powersaving" or "reduced", which are also accepted by FYNE_X_MOTION.
+const MotionPreferenceKey = "fyne-x.motion"
+
+const motionPollInterval = time.Millisecond * 100
+
+var (
+	motionPolicy   MotionPolicy
+	motionInactive = make(map[fyne.Canvas]bool)
+	motionLock     sync.RWMutex
+)
+
+// CurrentMotionPolicy returns the policy that animations are currently following.
+// This will never return MotionAuto, as that is resolved to the configured policy.
+func CurrentMotionPolicy() MotionPolicy {
+	motionLock.RLock()
+	policy := motionPolicy
+	motionLock.RUnlock()
+	if policy != MotionAuto {
+		return policy
+	}
+
+	if env, ok := os.LookupEnv("FYNE_X_MOTION"); ok {
+		return parseMotionPolicy(env)
+	}
+	if app := fyne.CurrentApp(); app != nil {
+		return parseMotionPolicy(app.Preferences().String(MotionPreferenceKey))
+	}
+	return MotionFull
+}
+
+// SetMotionPolicy sets the policy for all animated widgets, overriding the environment and preferences.
+// Setting MotionAuto will return to reading the configured policy.
+func SetMotionPolicy(policy MotionPolicy) {
+	motionLock.Lock()
+	motionPolicy = policy
+	motionLock.Unlock()
+}
+
+// SetWindowActive records whether a window is currently active (visible and focused).
+// Animations pause automatically under MotionPowerSaving once their window is closed, but this version of Fyne
+// has no events for a window being hidden or losing focus, so animations in a hidden or background window keep
+// playing unless the app calls SetWindowActive itself. Apps that want them paused must call it with false when
+// the window is hidden or loses focus, and with true when it is shown again. Windows are considered active until
+// this is called, and are forgotten once they have been closed.
+func SetWindowActive(w fyne.Window, active bool) {
+	motionLock.Lock()
+	defer motionLock.Unlock()
+	if active {
+		delete(motionInactive, w.Canvas())
+	} else {
+		motionInactive[w.Canvas()] = true
+	}
+}
+
+// animationAllowed returns true if the motion policy allows the object to animate right now.
+func animationAllowed(o fyne.CanvasObject) bool {
+	switch CurrentMotionPolicy() {
+	case MotionReduced:
+		return false
+	case MotionPowerSaving:
+		if !o.Visible() {
+			return false
+		}
+		app := fyne.CurrentApp()
+		if app == nil {
+			return false // nothing can be shown without an app
+		}
+		windows := app.Driver().AllWindows()
+		if len(windows) == 0 {
+			return false
+		}
+		c := app.Driver().CanvasForObject(o)
+		if c == nil {
+			return false
+		}
+
+		return canvasActive(windows, c)
+	default:
+		return true
+	}
+}
+
+// canvasActive returns true if the canvas belongs to one of the open windows, and that window has not been
+// reported inactive. Windows that have been closed are forgotten, so that their canvas can be released.
+func canvasActive(windows []fyne.Window, c fyne.Canvas) bool {
+	open := make(map[fyne.Canvas]bool, len(windows))
+	for _, w := range windows {
+		open[w.Canvas()] = true
+	}
+
+	motionLock.Lock()
+	defer motionLock.Unlock()
+	for inactive := range motionInactive {
+		if !open[inactive] {
+			delete(motionInactive, inactive)
+		}
+	}
+	return open[c] && !motionInactive[c]
+}
+
+func parseMotionPolicy(s string) MotionPolicy {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "powersaving":
+		return MotionPowerSaving
+	case "reduced":
+		return MotionReduced
+	default:
+		return MotionFull
+	}
+}
+
+// waitForMotion blocks while the motion policy does not allow the object to animate.
+// It returns false if the animation was stopped while waiting.
+func waitForMotion(o fyne.CanvasObject, stopping func() bool) bool {
+	for !animationAllowed(o) {
+		if stopping() {
+			return false
+		}
+		time.Sleep(motionPollInterval)
+	}
+	return !stopping()
+}
@@ -0,0 +1,94 @@
+package widget
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestCurrentMotionPolicy(t *testing.T) {
+	a := test.NewApp()
+	defer SetMotionPolicy(MotionAuto)
+	assert.Equal(t, MotionFull, CurrentMotionPolicy())
+
+	a.Preferences().SetString(MotionPreferenceKey, "reduced")
+	assert.Equal(t, MotionReduced, CurrentMotionPolicy())
+
+	os.Setenv("FYNE_X_MOTION", "PowerSaving")
+	defer os.Unsetenv("FYNE_X_MOTION")
+	assert.Equal(t, MotionPowerSaving, CurrentMotionPolicy())
+
+	SetMotionPolicy(MotionFull)
+	assert.Equal(t, MotionFull, CurrentMotionPolicy())
+}
+
+func TestAnimationAllowed(t *testing.T) {
+	test.NewApp()
+	defer SetMotionPolicy(MotionAuto)
+	label := widget.NewLabel("animated")
+	assert.True(t, animationAllowed(label))
+
+	SetMotionPolicy(MotionReduced)
+	assert.False(t, animationAllowed(label))
+
+	w := test.NewWindow(label)
+	defer w.Close()
+	SetMotionPolicy(MotionPowerSaving)
+	assert.True(t, animationAllowed(label))
+
+	SetWindowActive(w, false)
+	assert.False(t, animationAllowed(label))
+	SetWindowActive(w, true)
+	assert.True(t, animationAllowed(label))
+
+	label.Hide()
+	assert.False(t, animationAllowed(label))
+}
+
+func TestAnimationAllowed_Lifecycle(t *testing.T) {
+	defer SetMotionPolicy(MotionAuto)
+	SetMotionPolicy(MotionPowerSaving)
+	label := widget.NewLabel("animated")
+
+	fyne.SetCurrentApp(nil)
+	assert.False(t, animationAllowed(label))
+	a := test.NewApp()
+
+	w := test.NewWindow(label)
+	c := w.Canvas()
+	assert.True(t, canvasActive(a.Driver().AllWindows(), c))
+	SetWindowActive(w, false)
+	assert.False(t, animationAllowed(label))
+	w.Close()
+	assert.False(t, canvasActive(a.Driver().AllWindows(), c))
+	motionLock.RLock()
+	assert.Len(t, motionInactive, 0)
+	motionLock.RUnlock()
+}
+
+func TestSpriteAnimation_ReducedMotion(t *testing.T) {
+	test.NewApp()
+	defer SetMotionPolicy(MotionAuto)
+	SetMotionPolicy(MotionReduced)
+
+	sprite, err := NewSpriteAnimationFromImage(createSpriteSheet(), 2, 2, 4, []time.Duration{time.Millisecond})
+	assert.NoError(t, err)
+	sprite.LoopCount = -1
+
+	sprite.Start()
+	time.Sleep(time.Millisecond * 20)
+	assert.True(t, sprite.isRunning())
+	assert.Equal(t, 0, sprite.currentFrame())
+
+	SetMotionPolicy(MotionFull)
+	assert.Eventually(t, func() bool {
+		return !sprite.isRunning()
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 3, sprite.currentFrame())
+}
@@ -0,0 +1,185 @@
+package widget
+
+import (
+	"errors"
+	"image"
+	"image/draw"
+	_ "image/jpeg" // support jpeg sprite sheets
+	_ "image/png"  // support png sprite sheets
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+var (
+	errSpriteGrid   = errors.New("sprite sheet grid must have at least one row and column")
+	errSpriteFrames = errors.New("sprite sheet frame count must be between 1 and rows * columns")
+	errSpriteDelays = errors.New("sprite sheet needs one delay, or one delay per frame")
+)
+
+// SpriteAnimation widget shows an animation made from frames laid out in a grid on a single image.
+// Frames are read left to right, top to bottom.
+type SpriteAnimation struct {
+	widget.BaseWidget
+	min  fyne.Size
+	fill AnimationFill
+
+	// LoopCount controls how often the animation repeats and uses the same values as a gif image.
+	// A value of 0 loops forever, -1 plays the frames once and n plays the frames n+1 times.
+	LoopCount int
+
+	animationPlayer
+	frames  []image.Image
+	delays  []time.Duration
+	current int // guarded by runLock, as it is changed during playback
+	dst     *canvas.Image
+}
+
+// NewSpriteAnimation creates a new widget loaded to show the sprite sheet at the specified URI.
+// The sheet is split into a grid of rows and cols, of which the first count cells are used as frames.
+// Delays should contain a duration for each frame, or a single duration used for all of them.
+// If there is an error loading the image it will be returned in the error value.
+func NewSpriteAnimation(u fyne.URI, rows, cols, count int, delays []time.Duration) (*SpriteAnimation, error) {
+	ret := newSpriteAnimation()
+	if u == nil {
+		return ret, nil
+	}
+
+	return ret, ret.Load(u, rows, cols, count, delays)
+}
+
+// NewSpriteAnimationFromImage creates a new widget showing the frames of an already decoded sprite sheet.
+// The parameters are the same as for NewSpriteAnimation.
+func NewSpriteAnimationFromImage(img image.Image, rows, cols, count int, delays []time.Duration) (*SpriteAnimation, error) {
+	ret := newSpriteAnimation()
+	return ret, ret.LoadImage(img, rows, cols, count, delays)
+}
+
+func newSpriteAnimation() *SpriteAnimation {
+	ret := &SpriteAnimation{}
+	ret.ExtendBaseWidget(ret)
+	ret.dst = &canvas.Image{}
+	ret.dst.FillMode = canvas.ImageFillContain
+	return ret
+}
+
+// CreateRenderer loads the widget renderer for this widget. This is an internal requirement for Fyne.
+func (s *SpriteAnimation) CreateRenderer() fyne.WidgetRenderer {
+	return &animationRenderer{widget: s, dst: s.dst}
+}
+
+// Load is used to change the sprite sheet shown.
+// It will read the image from the URI and then split it into frames as described for LoadImage.
+func (s *SpriteAnimation) Load(u fyne.URI, rows, cols, count int, delays []time.Duration) error {
+	s.dst.Image = nil
+	s.dst.Refresh()
+
+	read, err := storage.Reader(u)
+	if err != nil {
+		return err
+	}
+	defer read.Close()
+	img, _, err := image.Decode(read)
+	if err != nil {
+		return err
+	}
+
+	return s.LoadImage(img, rows, cols, count, delays)
+}
+
+// LoadImage is used to change the sprite sheet to an already decoded image.
+// The image is split into a grid of rows and cols, of which the first count cells are used as frames.
+func (s *SpriteAnimation) LoadImage(img image.Image, rows, cols, count int, delays []time.Duration) error {
+	if rows < 1 || cols < 1 {
+		return errSpriteGrid
+	}
+	if count < 1 || count > rows*cols {
+		return errSpriteFrames
+	}
+	if len(delays) != 1 && len(delays) != count {
+		return errSpriteDelays
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx()/cols, bounds.Dy()/rows
+	frames := make([]image.Image, count)
+	frameDelays := make([]time.Duration, count)
+	for i := range frames {
+		min := bounds.Min.Add(image.Pt((i%cols)*width, (i/cols)*height))
+		frame := image.NewNRGBA(image.Rect(0, 0, width, height))
+		draw.Draw(frame, frame.Bounds(), img, min, draw.Src)
+		frames[i] = frame
+
+		if len(delays) == 1 {
+			frameDelays[i] = delays[0]
+		} else {
+			frameDelays[i] = delays[i]
+		}
+	}
+
+	s.frames = frames
+	s.delays = frameDelays
+	s.showFrame(0)
+	return nil
+}
+
+// MinSize returns the minimum size that this animation can occupy.
+// Because sprite sheets are measured in pixels we cannot use the dimensions, so this defaults to 0x0.
+// You can set a minimum size if required using SetMinSize.
+func (s *SpriteAnimation) MinSize() fyne.Size {
+	return s.min
+}
+
+// SetFillMode changes how the frames are scaled into the space available to this SpriteAnimation.
+// The default is AnimationFillContain.
+func (s *SpriteAnimation) SetFillMode(fill AnimationFill) {
+	s.fill = fill
+	s.layoutFrame()
+}
+
+// SetMinSize sets the smallest possible size that this SpriteAnimation should be drawn at.
+// Be careful not to set this based on pixel sizes as that will vary based on output device.
+func (s *SpriteAnimation) SetMinSize(min fyne.Size) {
+	s.min = min
+}
+
+// Start begins the animation. The speed of the transition is controlled by the frame delays.
+// The animation will pause, showing a static frame, while the CurrentMotionPolicy does not allow it to play.
+func (s *SpriteAnimation) Start() {
+	s.play(s, loopsForCount(s.LoopCount), len(s.frames), func(c int) time.Duration {
+		s.showFrame(c)
+		return s.delays[c]
+	})
+}
+
+// Stop will request that the animation stops running, the last frame will remain visible
+func (s *SpriteAnimation) Stop() {
+	s.stop()
+}
+
+// currentFrame returns the index of the frame that is shown.
+func (s *SpriteAnimation) currentFrame() int {
+	s.runLock.RLock()
+	defer s.runLock.RUnlock()
+	return s.current
+}
+
+// layoutFrame shows the current frame again, such as after the size or fill mode has changed.
+func (s *SpriteAnimation) layoutFrame() {
+	s.showFrame(s.currentFrame())
+}
+
+func (s *SpriteAnimation) showFrame(index int) {
+	s.runLock.Lock()
+	s.current = index
+	s.runLock.Unlock()
+
+	var frame image.Image
+	if index < len(s.frames) {
+		frame = s.frames[index]
+	}
+	layoutAnimationFrame(s, s.dst, frame, s.fill, s.Size())
+}
@@ -0,0 +1,103 @@
+package widget
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+)
+
+var spriteColors = []color.NRGBA{
+	{R: 255, A: 255},
+	{G: 255, A: 255},
+	{B: 255, A: 255},
+	{R: 255, G: 255, A: 255},
+}
+
+// createSpriteSheet returns a 2x2 sheet of 4x4 pixel frames, each filled with one of spriteColors.
+func createSpriteSheet() image.Image {
+	sheet := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	for i, c := range spriteColors {
+		r := image.Rect(0, 0, 4, 4).Add(image.Pt((i%2)*4, (i/2)*4))
+		draw.Draw(sheet, r, image.NewUniform(c), image.Point{}, draw.Src)
+	}
+	return sheet
+}
+
+func TestNewSpriteAnimation(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "sprite")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	file := path.Join(tempDir, "sheet.png")
+	out, err := os.Create(file)
+	assert.NoError(t, err)
+	assert.NoError(t, png.Encode(out, createSpriteSheet()))
+	out.Close()
+
+	sprite, err := NewSpriteAnimation(storage.NewFileURI(file), 2, 2, 3, []time.Duration{time.Millisecond})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(sprite.frames))
+	assert.Equal(t, sprite.frames[0], sprite.dst.Image)
+	for i, frame := range sprite.frames {
+		assert.Equal(t, image.Rect(0, 0, 4, 4), frame.Bounds())
+		assert.Equal(t, spriteColors[i], frame.At(2, 2))
+		assert.Equal(t, time.Millisecond, sprite.delays[i])
+	}
+}
+
+func TestNewSpriteAnimationFromImage_Errors(t *testing.T) {
+	sheet := createSpriteSheet()
+	delay := []time.Duration{time.Millisecond}
+
+	_, err := NewSpriteAnimationFromImage(sheet, 0, 2, 1, delay)
+	assert.Equal(t, errSpriteGrid, err)
+	_, err = NewSpriteAnimationFromImage(sheet, 2, 2, 5, delay)
+	assert.Equal(t, errSpriteFrames, err)
+	_, err = NewSpriteAnimationFromImage(sheet, 2, 2, 4, []time.Duration{time.Millisecond, time.Second})
+	assert.Equal(t, errSpriteDelays, err)
+}
+
+func TestSpriteAnimation_Start(t *testing.T) {
+	delays := []time.Duration{time.Millisecond, time.Millisecond, time.Millisecond, time.Millisecond}
+	sprite, err := NewSpriteAnimationFromImage(createSpriteSheet(), 2, 2, 4, delays)
+	assert.NoError(t, err)
+	sprite.LoopCount = -1
+
+	sprite.Start()
+	assert.True(t, sprite.isRunning())
+	assert.Eventually(t, func() bool {
+		return !sprite.isRunning()
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 0, sprite.remaining)
+	assert.Equal(t, sprite.frames[3], sprite.dst.Image)
+
+	sprite.LoopCount = 0
+	sprite.Start()
+	time.Sleep(time.Millisecond * 10)
+	assert.Equal(t, -1, sprite.loopsRemaining())
+	sprite.Stop()
+	assert.Eventually(t, func() bool {
+		return !sprite.isRunning()
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, -1, sprite.remaining)
+}
+
+func TestSpriteAnimation_MinSize(t *testing.T) {
+	sprite, _ := NewSpriteAnimation(nil, 0, 0, 0, nil)
+	assert.True(t, sprite.MinSize().IsZero())
+
+	sprite.SetMinSize(fyne.NewSize(10.0, 10.0))
+	assert.Equal(t, float32(10), sprite.MinSize().Width)
+	assert.Equal(t, float32(10), sprite.MinSize().Height)
+}